@@ -0,0 +1,98 @@
+package golamap
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TravelAdvisory is a parsed Route.TravelAdvisory
+type TravelAdvisory struct {
+	Segments []AdvisorySegment
+}
+
+// AdvisorySegment is the stretch of a route's overview geometry between
+// the points at Start and End, with its traffic Value
+type AdvisorySegment struct {
+	Start    int        // Index of the first point in the overview geometry
+	End      int        // Index of the last point in the overview geometry
+	Value    int        // Congestion or speed value reported by the API
+	Path     []Location // Points Start through End; set by Route.Advisory
+	Distance float64    // Length of Path in meters; set by Route.Advisory
+}
+
+// ParseTravelAdvisory parses a travel advisory such as
+// "0,1,0 | 1,3,15 | 3,4,10". The segments have no Path or Distance; use
+// Route.Advisory to join them to the overview geometry.
+func ParseTravelAdvisory(advisory string) (TravelAdvisory, error) {
+	var parsed TravelAdvisory
+	if strings.TrimSpace(advisory) == "" {
+		return parsed, nil
+	}
+
+	for _, segment := range strings.Split(advisory, "|") {
+		fields := strings.Split(segment, ",")
+		if len(fields) != 3 {
+			return TravelAdvisory{}, fmt.Errorf("invalid travel advisory segment %q", strings.TrimSpace(segment))
+		}
+
+		var values [3]int
+		for i, field := range fields {
+			v, err := strconv.Atoi(strings.TrimSpace(field))
+			if err != nil {
+				return TravelAdvisory{}, fmt.Errorf("invalid travel advisory segment %q", strings.TrimSpace(segment))
+			}
+			values[i] = v
+		}
+		if values[0] < 0 || values[1] < values[0] {
+			return TravelAdvisory{}, fmt.Errorf("invalid travel advisory segment %q", strings.TrimSpace(segment))
+		}
+
+		parsed.Segments = append(parsed.Segments, AdvisorySegment{Start: values[0], End: values[1], Value: values[2]})
+	}
+
+	return parsed, nil
+}
+
+// Advisory parses TravelAdvisory and joins each segment to its stretch of
+// the decoded OverviewPolyline. The route must have been requested with
+// DirectionsRequest.TrafficMetadata and a full Overview.
+func (r Route) Advisory() (TravelAdvisory, error) {
+	advisory, err := ParseTravelAdvisory(r.TravelAdvisory)
+	if err != nil {
+		return TravelAdvisory{}, err
+	}
+
+	geometry, err := r.Geometry()
+	if err != nil {
+		return TravelAdvisory{}, err
+	}
+
+	for i, segment := range advisory.Segments {
+		if segment.End >= len(geometry) {
+			return TravelAdvisory{}, fmt.Errorf("travel advisory segment %d,%d is outside the %d point overview polyline", segment.Start, segment.End, len(geometry))
+		}
+
+		segment.Path = geometry[segment.Start : segment.End+1]
+		for j := 1; j < len(segment.Path); j++ {
+			segment.Distance += segment.Path[j-1].Distance(segment.Path[j])
+		}
+		advisory.Segments[i] = segment
+	}
+
+	return advisory, nil
+}
+
+// Distance returns the total length in meters of the segments for which
+// match returns true, e.g. the congested distance of a route:
+//
+//	congested := advisory.Distance(func(s golamap.AdvisorySegment) bool { return s.Value > 0 })
+func (a TravelAdvisory) Distance(match func(AdvisorySegment) bool) float64 {
+	var distance float64
+	for _, segment := range a.Segments {
+		if match(segment) {
+			distance += segment.Distance
+		}
+	}
+	return distance
+}
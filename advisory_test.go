@@ -0,0 +1,59 @@
+package golamap
+
+import (
+	"testing"
+
+	"github.com/golang-mitrah/golamap/polyline"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTravelAdvisory(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		advisory, err := ParseTravelAdvisory("0,1,0 | 1,3,15 | 3,4,10")
+		assert.Nil(t, err)
+		assert.Equal(t, []AdvisorySegment{
+			{Start: 0, End: 1, Value: 0},
+			{Start: 1, End: 3, Value: 15},
+			{Start: 3, End: 4, Value: 10},
+		}, advisory.Segments)
+	})
+	t.Run("empty", func(t *testing.T) {
+		advisory, err := ParseTravelAdvisory("")
+		assert.Nil(t, err)
+		assert.Empty(t, advisory.Segments)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ParseTravelAdvisory("0,1,0 | 1,3")
+		assert.EqualError(t, err, `invalid travel advisory segment "1,3"`)
+		_, err = ParseTravelAdvisory("3,1,0")
+		assert.EqualError(t, err, `invalid travel advisory segment "3,1,0"`)
+		_, err = ParseTravelAdvisory("0,x,0")
+		assert.EqualError(t, err, `invalid travel advisory segment "0,x,0"`)
+	})
+}
+
+func TestRouteAdvisory(t *testing.T) {
+	points := []polyline.Point{
+		{Lat: 12.90, Lng: 77.60}, {Lat: 12.91, Lng: 77.60}, {Lat: 12.92, Lng: 77.60}, {Lat: 12.93, Lng: 77.60}, {Lat: 12.94, Lng: 77.60},
+	}
+	route := Route{
+		OverviewPolyline: polyline.Encode(points),
+		TravelAdvisory:   "0,1,0 | 1,3,15 | 3,4,10",
+	}
+
+	t.Run("success", func(t *testing.T) {
+		advisory, err := route.Advisory()
+		assert.Nil(t, err)
+		assert.Len(t, advisory.Segments, 3)
+		assert.Equal(t, []Location{{12.91, 77.60}, {12.92, 77.60}, {12.93, 77.60}}, advisory.Segments[1].Path)
+		assert.InDelta(t, 1112, advisory.Segments[0].Distance, 1)
+		assert.InDelta(t, 2224, advisory.Segments[1].Distance, 1)
+
+		congested := advisory.Distance(func(s AdvisorySegment) bool { return s.Value > 0 })
+		assert.InDelta(t, 3336, congested, 2)
+	})
+	t.Run("outside geometry", func(t *testing.T) {
+		_, err := Route{OverviewPolyline: route.OverviewPolyline, TravelAdvisory: "0,5,0"}.Advisory()
+		assert.EqualError(t, err, "travel advisory segment 0,5 is outside the 5 point overview polyline")
+	})
+}
@@ -0,0 +1,213 @@
+package golamap
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Cache stores API responses for WithCache. Implementations must be safe
+// for concurrent use; a backend that fails to read or write should report
+// a miss rather than an error.
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte, ttl time.Duration)
+}
+
+// DefaultCacheTTLs returns the cache lifetimes WithCache uses when given
+// no TTLs: endpoints whose answers rarely change for the same input
+func DefaultCacheTTLs() map[Endpoint]time.Duration {
+	return map[Endpoint]time.Duration{
+		EndpointGeoCode:        24 * time.Hour,
+		EndpointReverseGeocode: 24 * time.Hour,
+		EndpointPlaceDetail:    24 * time.Hour,
+		EndpointStyleDetails:   time.Hour,
+	}
+}
+
+// WithCache caches JSON responses in cache, keyed by endpoint and
+// normalized parameters. Only endpoints with a positive TTL are cached;
+// a nil ttls uses DefaultCacheTTLs. Static map images are never cached.
+//
+//	olaMap := golamap.NewClient(golamap.WithCache(golamap.NewLRUCache(10000), nil))
+func WithCache(cache Cache, ttls map[Endpoint]time.Duration) Option {
+	return func(o *OLAMap) {
+		if ttls == nil {
+			ttls = DefaultCacheTTLs()
+		}
+		o.cache = &responseCache{
+			backend: cache,
+			ttls:    ttls,
+			stats:   map[Endpoint]CacheCounts{},
+		}
+	}
+}
+
+// CacheCounts counts cache lookups
+type CacheCounts struct {
+	Hits   int64
+	Misses int64
+}
+
+// CacheStats reports the cache hits and misses of a client, in total and
+// per endpoint
+type CacheStats struct {
+	CacheCounts
+	Endpoints map[Endpoint]CacheCounts
+}
+
+// CacheStats returns the client's cache hits and misses so far
+func (o *OLAMap) CacheStats() CacheStats {
+	stats := CacheStats{Endpoints: map[Endpoint]CacheCounts{}}
+	if o.cache == nil {
+		return stats
+	}
+
+	o.cache.mu.Lock()
+	defer o.cache.mu.Unlock()
+	for e, counts := range o.cache.stats {
+		stats.Endpoints[e] = counts
+		stats.Hits += counts.Hits
+		stats.Misses += counts.Misses
+	}
+	return stats
+}
+
+type responseCache struct {
+	backend Cache
+	ttls    map[Endpoint]time.Duration
+
+	mu    sync.Mutex
+	stats map[Endpoint]CacheCounts
+}
+
+func (c *responseCache) count(e Endpoint, hit bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	counts := c.stats[e]
+	if hit {
+		counts.Hits++
+	} else {
+		counts.Misses++
+	}
+	c.stats[e] = counts
+}
+
+// cacheKey identifies a request by endpoint, method, path and query, with
+// the api_key removed and parameters sorted
+func cacheKey(endpoint Endpoint, method, apiURL string) string {
+	u, err := url.Parse(apiURL)
+	if err != nil {
+		return string(endpoint) + " " + method + " " + apiURL
+	}
+
+	query := u.Query()
+	query.Del("api_key")
+	return string(endpoint) + " " + method + " " + u.Path + "?" + query.Encode()
+}
+
+// sendCached serves a JSON request from the cache when endpoint is cached,
+// storing fresh responses
+func (o *OLAMap) sendCached(ctx context.Context, endpoint Endpoint, method, apiURL string, cred Credential, responseObj interface{}) error {
+	ttl := o.cache.ttls[endpoint]
+	if ttl <= 0 {
+		return o.sendShared(ctx, endpoint, method, apiURL, cred, responseObj)
+	}
+
+	key := cacheKey(endpoint, method, apiURL)
+	if cached, ok := o.cache.backend.Get(key); ok && json.Unmarshal(cached, responseObj) == nil {
+		o.cache.count(endpoint, true)
+		return nil
+	}
+	o.cache.count(endpoint, false)
+
+	if err := o.sendShared(ctx, endpoint, method, apiURL, cred, responseObj); err != nil {
+		return err
+	}
+	if data, err := json.Marshal(responseObj); err == nil {
+		o.cache.backend.Set(key, data, ttl)
+	}
+
+	return nil
+}
+
+// LRUCache is an in-memory Cache holding up to a fixed number of entries,
+// evicting the least recently used
+type LRUCache struct {
+	capacity int
+	now      func() time.Time
+
+	mu      sync.Mutex
+	order   *list.List // Front is most recently used
+	entries map[string]*list.Element
+}
+
+type lruEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// NewLRUCache returns an LRUCache holding up to capacity entries
+func NewLRUCache(capacity int) *LRUCache {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &LRUCache{
+		capacity: capacity,
+		now:      time.Now,
+		order:    list.New(),
+		entries:  map[string]*list.Element{},
+	}
+}
+
+// Get returns the unexpired value stored under key
+func (c *LRUCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*lruEntry)
+	if !c.now().Before(entry.expires) {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+		return nil, false
+	}
+
+	c.order.MoveToFront(elem)
+	return entry.value, true
+}
+
+// Set stores value under key for ttl
+func (c *LRUCache) Set(key string, value []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expires := c.now().Add(ttl)
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*lruEntry)
+		entry.value, entry.expires = value, expires
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, value: value, expires: expires})
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).key)
+	}
+}
+
+// Len returns the number of entries, including expired ones not yet evicted
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
@@ -0,0 +1,129 @@
+package golamap
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type failingService struct{ calls int }
+
+func (f *failingService) SendOlaMapRequest(ctx context.Context, method, url, requestID, oauthToken string, responseObj interface{}) error {
+	f.calls++
+	return errors.New("mock failure")
+}
+
+func TestLRUCache(t *testing.T) {
+	t.Run("evicts least recently used", func(t *testing.T) {
+		cache := NewLRUCache(2)
+		cache.Set("a", []byte("1"), time.Minute)
+		cache.Set("b", []byte("2"), time.Minute)
+		_, ok := cache.Get("a")
+		assert.True(t, ok)
+
+		cache.Set("c", []byte("3"), time.Minute)
+		_, ok = cache.Get("b")
+		assert.False(t, ok, "b was least recently used")
+		value, ok := cache.Get("a")
+		assert.True(t, ok)
+		assert.Equal(t, []byte("1"), value)
+		assert.Equal(t, 2, cache.Len())
+	})
+	t.Run("expires entries", func(t *testing.T) {
+		now := time.Now()
+		cache := NewLRUCache(10)
+		cache.now = func() time.Time { return now }
+		cache.Set("a", []byte("1"), time.Minute)
+
+		now = now.Add(59 * time.Second)
+		_, ok := cache.Get("a")
+		assert.True(t, ok)
+
+		now = now.Add(time.Second)
+		_, ok = cache.Get("a")
+		assert.False(t, ok)
+		assert.Equal(t, 0, cache.Len())
+	})
+	t.Run("overwrites", func(t *testing.T) {
+		cache := NewLRUCache(10)
+		cache.Set("a", []byte("1"), time.Minute)
+		cache.Set("a", []byte("2"), time.Minute)
+		value, _ := cache.Get("a")
+		assert.Equal(t, []byte("2"), value)
+		assert.Equal(t, 1, cache.Len())
+	})
+}
+
+func TestWithCache(t *testing.T) {
+	t.Run("serves repeated calls from the cache", func(t *testing.T) {
+		mocking := &fixtureService{body: GeoCodeResponse}
+		olaMap := NewClient(WithHttpService(mocking), WithAPIKey("mock-key"), WithCache(NewLRUCache(100), nil))
+
+		first, err := olaMap.GeoCode("mumbai", Bounds{}, "")
+		assert.Nil(t, err)
+		second, err := olaMap.GeoCode("mumbai", Bounds{}, "")
+		assert.Nil(t, err)
+		assert.Equal(t, first, second)
+		assert.Len(t, mocking.urls, 1)
+
+		_, err = olaMap.GeoCode("delhi", Bounds{}, "")
+		assert.Nil(t, err)
+		assert.Len(t, mocking.urls, 2)
+
+		stats := olaMap.CacheStats()
+		assert.Equal(t, CacheCounts{Hits: 1, Misses: 2}, stats.CacheCounts)
+		assert.Equal(t, CacheCounts{Hits: 1, Misses: 2}, stats.Endpoints[EndpointGeoCode])
+	})
+	t.Run("per-endpoint TTLs", func(t *testing.T) {
+		mocking := &fixtureService{body: ReverseGeocodeResponse}
+		olaMap := NewClient(WithHttpService(mocking), WithAPIKey("mock-key"), WithCache(NewLRUCache(100), map[Endpoint]time.Duration{
+			EndpointGeoCode: time.Hour,
+		}))
+
+		for i := 0; i < 2; i++ {
+			_, err := olaMap.ReverseGeocode(LatLng{Lat: 12.93, Lng: 77.61})
+			assert.Nil(t, err)
+		}
+		assert.Len(t, mocking.urls, 2, "reverse geocode has no TTL")
+		assert.Empty(t, olaMap.CacheStats().Endpoints)
+	})
+	t.Run("shared across credentials", func(t *testing.T) {
+		cache := NewLRUCache(100)
+		mocking := &fixtureService{body: PlaceDetailResponse}
+		_, err := NewClient(WithHttpService(mocking), WithAPIKey("first-key"), WithCache(cache, nil)).GetPlaceDetail("mock-place-id")
+		assert.Nil(t, err)
+		_, err = NewClient(WithHttpService(mocking), WithAPIKey("second-key"), WithCache(cache, nil)).GetPlaceDetail("mock-place-id")
+		assert.Nil(t, err)
+		assert.Len(t, mocking.urls, 1, "the api_key is not part of the cache key")
+	})
+	t.Run("errors are not cached", func(t *testing.T) {
+		failing := &failingService{}
+		olaMap := NewClient(WithHttpService(failing), WithAPIKey("mock-key"), WithCache(NewLRUCache(100), nil))
+		for i := 0; i < 2; i++ {
+			_, err := olaMap.GeoCode("mumbai", Bounds{}, "")
+			assert.EqualError(t, err, "failed to send request to Olamaps API: mock failure")
+		}
+		assert.Equal(t, 2, failing.calls)
+		assert.Equal(t, int64(2), olaMap.CacheStats().Misses)
+	})
+	t.Run("cached style details keep the api key", func(t *testing.T) {
+		mocking := &fixtureService{body: StyleDetailResponse}
+		olaMap := NewClient(WithHttpService(mocking), WithAPIKey("mock-key"), WithCache(NewLRUCache(100), nil))
+		first, err := olaMap.GetStyleDetails("mock-style")
+		assert.Nil(t, err)
+		second, err := olaMap.GetStyleDetails("mock-style")
+		assert.Nil(t, err)
+		assert.Equal(t, first, second)
+		assert.Len(t, mocking.urls, 1)
+	})
+}
+
+func TestCacheKey(t *testing.T) {
+	a := cacheKey(EndpointGeoCode, "GET", "https://api.olamaps.io/places/v1/geocode?language=&address=mumbai&bounds=&api_key=one")
+	b := cacheKey(EndpointGeoCode, "GET", "http://localhost/places/v1/geocode?address=mumbai&bounds=&language=")
+	assert.Equal(t, a, b)
+	assert.Equal(t, "geocode GET /places/v1/geocode?address=mumbai&bounds=&language=", a)
+}
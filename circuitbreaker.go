@@ -0,0 +1,256 @@
+package golamap
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// CircuitBreaker stops sending requests to an APIFamily that keeps failing.
+// After FailureThreshold consecutive failures the circuit opens and calls
+// fail at once with an error matching ErrCircuitOpen. Once CoolDown has
+// passed it is half-open: up to HalfOpenRequests trial calls go through,
+// and the circuit closes when they all succeed or opens again on the first
+// failure. Zero fields take the defaults noted below.
+type CircuitBreaker struct {
+	FailureThreshold int           // Consecutive failures that open the circuit; defaults to 5
+	CoolDown         time.Duration // Time the circuit stays open; defaults to 30s
+	HalfOpenRequests int           // Trial calls allowed while half-open; defaults to 1
+
+	// IsFailure decides whether a call counts against the circuit. It
+	// defaults to connection errors and 5xx responses. Calls whose own
+	// context was canceled, or that the client's rate limit turned away,
+	// never count.
+	IsFailure func(resp *http.Response, err error) bool
+
+	// OnStateChange is called, outside any lock, whenever the circuit
+	// changes state, e.g. to raise an alert
+	OnStateChange func(CircuitStateChange)
+}
+
+// CircuitState is the state of a circuit breaker
+type CircuitState int
+
+const (
+	CircuitClosed   CircuitState = iota // Calls go through
+	CircuitOpen                         // Calls fail with ErrCircuitOpen
+	CircuitHalfOpen                     // Trial calls go through
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return fmt.Sprintf("CircuitState(%d)", int(s))
+}
+
+// CircuitStateChange describes a transition, as passed to
+// CircuitBreaker.OnStateChange
+type CircuitStateChange struct {
+	Family APIFamily
+	From   CircuitState
+	To     CircuitState
+}
+
+// WithCircuitBreaker guards every endpoint of family with breaker. Retries
+// happen inside the breaker, so a call counts once however often it is
+// retried. It does not apply to a custom HttpService.
+func WithCircuitBreaker(family APIFamily, breaker CircuitBreaker) Option {
+	return func(o *OLAMap) {
+		if o.circuitBreakers == nil {
+			o.circuitBreakers = map[APIFamily]*circuitBreaker{}
+		}
+		o.circuitBreakers[family] = newCircuitBreaker(family, breaker, time.Now)
+	}
+}
+
+// CircuitState returns the state of family's circuit breaker, which is
+// CircuitClosed when it has none
+func (o *OLAMap) CircuitState(family APIFamily) CircuitState {
+	breaker, ok := o.circuitBreakers[family]
+	if !ok {
+		return CircuitClosed
+	}
+	return breaker.current()
+}
+
+// circuitBreaker tracks the state of one APIFamily's circuit
+type circuitBreaker struct {
+	family APIFamily
+	config CircuitBreaker
+	now    func() time.Time
+
+	mu         sync.Mutex
+	state      CircuitState
+	generation uint64 // Bumped on every transition, so stale outcomes are ignored
+	failures   int    // Consecutive failures while closed
+	openedAt   time.Time
+	trials     int // Trial calls admitted while half-open
+	successes  int // Trial calls that succeeded while half-open
+}
+
+func newCircuitBreaker(family APIFamily, config CircuitBreaker, now func() time.Time) *circuitBreaker {
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = 5
+	}
+	if config.CoolDown <= 0 {
+		config.CoolDown = 30 * time.Second
+	}
+	if config.HalfOpenRequests <= 0 {
+		config.HalfOpenRequests = 1
+	}
+	if config.IsFailure == nil {
+		config.IsFailure = defaultIsFailure
+	}
+	return &circuitBreaker{family: family, config: config, now: now}
+}
+
+func defaultIsFailure(resp *http.Response, err error) bool {
+	return err != nil || resp.StatusCode >= 500
+}
+
+func (b *circuitBreaker) current() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == CircuitOpen && b.cooledDown() {
+		return CircuitHalfOpen
+	}
+	return b.state
+}
+
+func (b *circuitBreaker) cooledDown() bool {
+	return !b.now().Before(b.openedAt.Add(b.config.CoolDown))
+}
+
+// allow admits a call, returning the generation its outcome belongs to
+func (b *circuitBreaker) allow() (uint64, error) {
+	b.mu.Lock()
+	var change *CircuitStateChange
+	defer func() {
+		b.mu.Unlock()
+		b.notify(change)
+	}()
+
+	if b.state == CircuitOpen {
+		if !b.cooledDown() {
+			return 0, b.openError()
+		}
+		change = b.transition(CircuitHalfOpen)
+	}
+	if b.state == CircuitHalfOpen {
+		if b.trials >= b.config.HalfOpenRequests {
+			return 0, b.openError()
+		}
+		b.trials++
+	}
+	return b.generation, nil
+}
+
+// record counts the outcome of a call admitted in generation
+func (b *circuitBreaker) record(generation uint64, failed bool) {
+	b.mu.Lock()
+	var change *CircuitStateChange
+	defer func() {
+		b.mu.Unlock()
+		b.notify(change)
+	}()
+
+	if generation != b.generation {
+		return
+	}
+
+	switch b.state {
+	case CircuitClosed:
+		if !failed {
+			b.failures = 0
+			return
+		}
+		b.failures++
+		if b.failures >= b.config.FailureThreshold {
+			change = b.transition(CircuitOpen)
+		}
+	case CircuitHalfOpen:
+		if failed {
+			change = b.transition(CircuitOpen)
+			return
+		}
+		b.successes++
+		if b.successes >= b.config.HalfOpenRequests {
+			change = b.transition(CircuitClosed)
+		}
+	}
+}
+
+// release returns a half-open trial whose outcome says nothing about the API
+func (b *circuitBreaker) release(generation uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if generation == b.generation && b.state == CircuitHalfOpen {
+		b.trials--
+	}
+}
+
+// transition moves to state, resetting the counters; b.mu must be held
+func (b *circuitBreaker) transition(state CircuitState) *CircuitStateChange {
+	change := &CircuitStateChange{Family: b.family, From: b.state, To: state}
+	b.state = state
+	b.generation++
+	b.failures, b.trials, b.successes = 0, 0, 0
+	if state == CircuitOpen {
+		b.openedAt = b.now()
+	}
+	return change
+}
+
+func (b *circuitBreaker) notify(change *CircuitStateChange) {
+	if change != nil && b.config.OnStateChange != nil {
+		b.config.OnStateChange(*change)
+	}
+}
+
+func (b *circuitBreaker) openError() error {
+	return &kindError{msg: fmt.Sprintf("Olamaps %s circuit breaker is open", b.family), kind: ErrCircuitOpen}
+}
+
+// circuitBreakerTransport guards each request with its APIFamily's breaker
+type circuitBreakerTransport struct {
+	next     http.RoundTripper
+	breakers map[APIFamily]*circuitBreaker
+}
+
+func (t *circuitBreakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := t.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	endpoint, ok := EndpointFromContext(req.Context())
+	if !ok {
+		return next.RoundTrip(req)
+	}
+	breaker, ok := t.breakers[endpoint.Family()]
+	if !ok {
+		return next.RoundTrip(req)
+	}
+
+	generation, err := breaker.allow()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := next.RoundTrip(req)
+	if req.Context().Err() != nil || errors.Is(err, ErrRateLimited) {
+		breaker.release(generation)
+		return resp, err
+	}
+	breaker.record(generation, breaker.config.IsFailure(resp, err))
+
+	return resp, err
+}
@@ -0,0 +1,160 @@
+package golamap
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreakerStates(t *testing.T) {
+	now := time.Now()
+	var changes []CircuitStateChange
+	breaker := newCircuitBreaker(APIPlaces, CircuitBreaker{
+		FailureThreshold: 2,
+		CoolDown:         time.Minute,
+		HalfOpenRequests: 2,
+		OnStateChange:    func(change CircuitStateChange) { changes = append(changes, change) },
+	}, func() time.Time { return now })
+
+	fail := func() {
+		generation, err := breaker.allow()
+		assert.Nil(t, err)
+		breaker.record(generation, true)
+	}
+	succeed := func() {
+		generation, err := breaker.allow()
+		assert.Nil(t, err)
+		breaker.record(generation, false)
+	}
+
+	fail()
+	succeed()
+	fail()
+	assert.Equal(t, CircuitClosed, breaker.current(), "failures must be consecutive")
+	fail()
+	assert.Equal(t, CircuitOpen, breaker.current())
+
+	_, err := breaker.allow()
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.EqualError(t, err, "Olamaps places circuit breaker is open")
+
+	now = now.Add(time.Minute)
+	assert.Equal(t, CircuitHalfOpen, breaker.current())
+	first, err := breaker.allow()
+	assert.Nil(t, err)
+	second, err := breaker.allow()
+	assert.Nil(t, err)
+	_, err = breaker.allow()
+	assert.ErrorIs(t, err, ErrCircuitOpen, "only HalfOpenRequests trials")
+
+	breaker.record(first, false)
+	breaker.record(second, true)
+	assert.Equal(t, CircuitOpen, breaker.current(), "a failed trial reopens")
+
+	now = now.Add(time.Minute)
+	succeed()
+	succeed()
+	assert.Equal(t, CircuitClosed, breaker.current())
+
+	assert.Equal(t, []CircuitStateChange{
+		{Family: APIPlaces, From: CircuitClosed, To: CircuitOpen},
+		{Family: APIPlaces, From: CircuitOpen, To: CircuitHalfOpen},
+		{Family: APIPlaces, From: CircuitHalfOpen, To: CircuitOpen},
+		{Family: APIPlaces, From: CircuitOpen, To: CircuitHalfOpen},
+		{Family: APIPlaces, From: CircuitHalfOpen, To: CircuitClosed},
+	}, changes)
+}
+
+func TestCircuitBreakerIgnoresStaleOutcomes(t *testing.T) {
+	breaker := newCircuitBreaker(APIPlaces, CircuitBreaker{FailureThreshold: 1}, time.Now)
+	stale, _ := breaker.allow()
+	current, _ := breaker.allow()
+	breaker.record(current, true)
+	assert.Equal(t, CircuitOpen, breaker.current())
+
+	breaker.record(stale, false)
+	assert.Equal(t, CircuitOpen, breaker.current())
+}
+
+func TestWithCircuitBreaker(t *testing.T) {
+	var status atomic.Int32
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(int(status.Load()))
+		w.Write([]byte(GeoCodeResponse))
+	}))
+	defer server.Close()
+
+	t.Run("opens after repeated failures", func(t *testing.T) {
+		status.Store(http.StatusServiceUnavailable)
+		hits.Store(0)
+		var changes []CircuitStateChange
+		olaMap := NewClient(WithBaseURL(APIPlaces, server.URL), WithAPIKey("mock-key"),
+			WithCircuitBreaker(APIPlaces, CircuitBreaker{
+				FailureThreshold: 3,
+				OnStateChange:    func(change CircuitStateChange) { changes = append(changes, change) },
+			}))
+
+		for i := 0; i < 3; i++ {
+			_, err := olaMap.GeoCode("mock-address", Bounds{}, "")
+			assert.NotErrorIs(t, err, ErrCircuitOpen)
+		}
+		_, err := olaMap.GeoCode("mock-address", Bounds{}, "")
+		assert.ErrorIs(t, err, ErrCircuitOpen)
+		assert.Equal(t, int32(3), hits.Load())
+		assert.Equal(t, CircuitOpen, olaMap.CircuitState(APIPlaces))
+		assert.Equal(t, CircuitClosed, olaMap.CircuitState(APIRouting))
+		assert.Equal(t, []CircuitStateChange{{Family: APIPlaces, From: CircuitClosed, To: CircuitOpen}}, changes)
+	})
+	t.Run("closes after a successful trial", func(t *testing.T) {
+		status.Store(http.StatusInternalServerError)
+		olaMap := NewClient(WithBaseURL(APIPlaces, server.URL), WithAPIKey("mock-key"),
+			WithCircuitBreaker(APIPlaces, CircuitBreaker{FailureThreshold: 1, CoolDown: 20 * time.Millisecond}))
+
+		olaMap.GeoCode("mock-address", Bounds{}, "")
+		assert.Equal(t, CircuitOpen, olaMap.CircuitState(APIPlaces))
+
+		status.Store(http.StatusOK)
+		time.Sleep(20 * time.Millisecond)
+		_, err := olaMap.GeoCode("mock-address", Bounds{}, "")
+		assert.Nil(t, err)
+		assert.Equal(t, CircuitClosed, olaMap.CircuitState(APIPlaces))
+	})
+	t.Run("retries count once", func(t *testing.T) {
+		status.Store(http.StatusServiceUnavailable)
+		hits.Store(0)
+		policy := DefaultRetryPolicy()
+		policy.InitialBackoff = time.Millisecond
+		olaMap := NewClient(WithBaseURL(APIPlaces, server.URL), WithAPIKey("mock-key"), WithRetryPolicy(policy),
+			WithCircuitBreaker(APIPlaces, CircuitBreaker{FailureThreshold: 2}))
+
+		olaMap.GeoCode("mock-address", Bounds{}, "")
+		assert.Equal(t, int32(4), hits.Load())
+		assert.Equal(t, CircuitClosed, olaMap.CircuitState(APIPlaces))
+	})
+	t.Run("client errors do not count", func(t *testing.T) {
+		status.Store(http.StatusBadRequest)
+		olaMap := NewClient(WithBaseURL(APIPlaces, server.URL), WithAPIKey("mock-key"),
+			WithCircuitBreaker(APIPlaces, CircuitBreaker{FailureThreshold: 1}))
+
+		olaMap.GeoCode("mock-address", Bounds{}, "")
+		assert.Equal(t, CircuitClosed, olaMap.CircuitState(APIPlaces))
+	})
+	t.Run("canceled calls do not count", func(t *testing.T) {
+		status.Store(http.StatusOK)
+		olaMap := NewClient(WithBaseURL(APIPlaces, server.URL), WithAPIKey("mock-key"),
+			WithCircuitBreaker(APIPlaces, CircuitBreaker{FailureThreshold: 1}))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := olaMap.GeoCodeWithContext(ctx, "mock-address", Bounds{}, "")
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, CircuitClosed, olaMap.CircuitState(APIPlaces))
+	})
+}
@@ -1,22 +1,44 @@
 package golamap
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 type OLAMap struct {
-	Token       string   // Ola map token
-	RequestId   string   // Unique UUID for a request
-	HttpService HttpServ // HTTP service interface
+	Token       string      // Ola map token; set by ConfigureAccessToken to the first token it fetches
+	APIKey      string      // Ola map API key, sent as the api_key query parameter when Token is empty
+	RequestId   string      // Unique UUID for a request
+	HttpService HttpServ    // HTTP service interface
+	TokenSource TokenSource // Supplies credentials per request; takes precedence over Token and APIKey
+
+	httpClient          *http.Client
+	baseURLs            map[APIFamily]string
+	userAgent           string
+	timeout             time.Duration
+	requestIDGenerator  func() string
+	retryPolicy         *RetryPolicy
+	rateLimiters        map[APIFamily]*rateLimiter
+	middleware          []Middleware
+	cache               *responseCache
+	reverseGeocodeCache *ReverseGeocodeCache
+	flights             *flightGroup
+	circuitBreakers     map[APIFamily]*circuitBreaker
+	logger              *slog.Logger
+	logOptions          *LogOptions
 }
 
 type HttpServ interface {
-	SendOlaMapRequest(method, url, requestID, oauthToken string, responseObj interface{}) error
+	SendOlaMapRequest(ctx context.Context, method, url, requestID, oauthToken string, responseObj interface{}) error
 }
 
 type TokenResponse struct {
@@ -27,43 +49,208 @@ type TokenResponse struct {
 
 // Initialize the Olamap with X-RequestID
 func Initialize(requestID string) *OLAMap {
-	httpReq := &OlaRequest{}
-	return &OLAMap{
-		RequestId:   requestID,
-		HttpService: httpReq,
-	}
+	return NewClient(WithRequestID(requestID))
+}
+
+// InitializeWithAPIKey initializes the Olamap with X-RequestID, authenticating
+// every request with an API key instead of an OAuth token
+func InitializeWithAPIKey(requestID, apiKey string) *OLAMap {
+	olaMap := Initialize(requestID)
+	olaMap.APIKey = apiKey
+	return olaMap
 }
 
 // Configure OLA access token
 func (o *OLAMap) ConfigureAccessToken(clientID, clientSecret string) error {
-	form := url.Values{}
-	form.Set("grant_type", "client_credentials")
-	form.Set("scope", "openid")
-	form.Set("client_id", clientID)
-	form.Set("client_secret", clientSecret)
+	return o.ConfigureAccessTokenWithContext(context.Background(), clientID, clientSecret)
+}
 
-	req, err := http.NewRequest("POST", TokenURL, strings.NewReader(form.Encode()))
+// ConfigureAccessTokenWithContext is ConfigureAccessToken bound to ctx, which is carried to the token request.
+// The credentials are remembered: the token is refreshed shortly before it
+// expires, and again whenever the API answers 401.
+func (o *OLAMap) ConfigureAccessTokenWithContext(ctx context.Context, clientID, clientSecret string) error {
+	tokens := NewClientCredentialsSource(clientID, clientSecret)
+	tokens.TokenURL = o.endpointURL(EndpointToken)
+	tokens.HTTPClient = o.client()
+	cred, err := tokens.Credential(ctx)
 	if err != nil {
 		return err
 	}
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	resp, err := http.DefaultClient.Do(req)
+	o.Token = cred.AccessToken
+	o.TokenSource = tokens
+
+	return nil
+}
+
+// client returns the http.Client for requests made outside HttpService
+func (o *OLAMap) client() *http.Client {
+	if o.httpClient != nil {
+		return o.httpClient
+	}
+	return http.DefaultClient
+}
+
+// requestID returns the X-Request-Id for the next request
+func (o *OLAMap) requestID() string {
+	if o.RequestId == "" && o.requestIDGenerator != nil {
+		return o.requestIDGenerator()
+	}
+	return o.RequestId
+}
+
+// credential returns the credential for the next request
+func (o *OLAMap) credential(ctx context.Context) (Credential, error) {
+	if o.TokenSource != nil {
+		cred, err := o.TokenSource.Credential(ctx)
+		if err != nil {
+			return Credential{}, err
+		}
+		if cred.AccessToken == "" && cred.APIKey == "" {
+			return Credential{}, unauthorizedError("Invalid OAuth token")
+		}
+		return cred, nil
+	}
+
+	if o.Token != "" {
+		return Credential{AccessToken: o.Token}, nil
+	}
+
+	if o.APIKey != "" {
+		return Credential{APIKey: o.APIKey}, nil
+	}
+
+	return Credential{}, unauthorizedError("Invalid OAuth token")
+}
+
+// refreshCredential tells the TokenSource a credential was rejected and
+// returns a fresh one. It reports false when the source cannot refresh.
+func (o *OLAMap) refreshCredential(ctx context.Context, rejected Credential) (Credential, bool) {
+	source, ok := o.TokenSource.(interface{ Invalidate(Credential) })
+	if !ok {
+		return Credential{}, false
+	}
+
+	source.Invalidate(rejected)
+	cred, err := o.credential(ctx)
 	if err != nil {
-		return err
+		return Credential{}, false
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return errors.New(fmt.Sprintf("Failed to get token - statuscode %v", resp.StatusCode))
+	return cred, true
+}
+
+// withAPIKey appends the credential's api_key, if any, to apiURL
+func withAPIKey(apiURL string, cred Credential) string {
+	if cred.APIKey == "" || apiURL == "" || strings.Contains(apiURL, "api_key=") {
+		return apiURL
 	}
 
-	var tokenResponse TokenResponse
-	if err := json.NewDecoder(resp.Body).Decode(&tokenResponse); err != nil {
-		return err
+	separator := "?"
+	if strings.Contains(apiURL, "?") {
+		separator = "&"
 	}
 
-	o.Token = "Bearer " + tokenResponse.AccessToken
+	return apiURL + separator + "api_key=" + url.QueryEscape(cred.APIKey)
+}
 
-	return nil
+// addAPIKey appends the api_key to the tile URLs of a TileJSON document, so
+// that browser clients can fetch the tiles directly
+func (a *ArrayOfData) addAPIKey(cred Credential) {
+	for i := range a.Tiles {
+		a.Tiles[i] = withAPIKey(a.Tiles[i], cred)
+	}
+}
+
+// addAPIKey appends the api_key to the source and glyph URLs of a style
+// document, so that browser clients can load the style directly
+func (v *VectorStyleDetails) addAPIKey(cred Credential) {
+	v.Glyphs = withAPIKey(v.Glyphs, cred)
+	for name, source := range v.Sources {
+		source.URL = withAPIKey(source.URL, cred)
+		v.Sources[name] = source
+	}
+}
+
+// addAPIKeyToStyles appends the api_key to each style's URL
+func addAPIKeyToStyles(styles []VectorMapStyle, cred Credential) {
+	for i := range styles {
+		styles[i].URL = withAPIKey(styles[i].URL, cred)
+	}
+}
+
+// send makes a JSON request through HttpService, answering from the cache
+// and coalescing identical requests when configured
+func (o *OLAMap) send(ctx context.Context, endpoint Endpoint, method, apiURL string, cred Credential, responseObj interface{}) error {
+	ctx = withEndpoint(ctx, endpoint)
+	if o.cache != nil {
+		return o.sendCached(ctx, endpoint, method, apiURL, cred, responseObj)
+	}
+	return o.sendShared(ctx, endpoint, method, apiURL, cred, responseObj)
+}
+
+// sendAuthorized makes a JSON request through HttpService, retrying once
+// with a fresh credential if the API rejects the current one
+func (o *OLAMap) sendAuthorized(ctx context.Context, endpoint Endpoint, method, apiURL string, cred Credential, responseObj interface{}) error {
+	requestID := o.requestID()
+	err := o.HttpService.SendOlaMapRequest(ctx, method, withAPIKey(apiURL, cred), requestID, cred.AccessToken, responseObj)
+	if isTokenRejected(err) {
+		if fresh, ok := o.refreshCredential(ctx, cred); ok {
+			err = o.HttpService.SendOlaMapRequest(ctx, method, withAPIKey(apiURL, fresh), requestID, fresh.AccessToken, responseObj)
+		}
+	}
+
+	return err
+}
+
+// isTokenRejected reports whether err is a 401 from the API. A 403 means the
+// credential is valid but not allowed, so a fresh one would not help.
+func isTokenRejected(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized
+}
+
+// getStaticImage fetches a static map image into image, retrying once with
+// a fresh credential if the API rejects the current one
+func (o *OLAMap) getStaticImage(ctx context.Context, endpoint Endpoint, apiURL string, cred Credential, image *StaticImage, stream bool) (*StaticImage, error) {
+	ctx = withEndpoint(ctx, endpoint)
+	resp, err := o.doStaticImageRequest(ctx, apiURL, cred)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		if fresh, ok := o.refreshCredential(ctx, cred); ok {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+
+			resp, err = o.doStaticImageRequest(ctx, apiURL, fresh)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return readStaticImage(resp, image, stream)
+}
+
+func (o *OLAMap) doStaticImageRequest(ctx context.Context, apiURL string, cred Credential) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", withAPIKey(apiURL, cred), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request to Olamaps API: %w", err)
+	}
+
+	req.Header.Add("X-Request-Id", o.requestID())
+	req.Header.Add("X-Correlation-Id", uuid.New().String())
+
+	if cred.AccessToken != "" {
+		req.Header.Add("Authorization", cred.AccessToken)
+	}
+
+	resp, err := o.client().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to make external request: %w", redactError(err))
+	}
+
+	return resp, nil
 }
@@ -0,0 +1,130 @@
+package golamap
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sync"
+)
+
+// WithRequestCoalescing makes concurrent identical requests share one
+// upstream call and its result. Requests are identical when they have the
+// same method, URL and credential. Only the given endpoints are coalesced,
+// or every JSON endpoint if none are given; static map images never are.
+// HttpService receives a response value of the caller's type, which is
+// then copied to every waiting caller.
+//
+// The shared call runs until it completes or every caller waiting on it
+// has given up, so one caller's canceled context does not fail the others.
+func WithRequestCoalescing(endpoints ...Endpoint) Option {
+	return func(o *OLAMap) {
+		group := &flightGroup{calls: map[string]*flight{}}
+		if len(endpoints) > 0 {
+			group.endpoints = map[Endpoint]bool{}
+			for _, e := range endpoints {
+				group.endpoints[e] = true
+			}
+		}
+		o.flights = group
+	}
+}
+
+type flightGroup struct {
+	endpoints map[Endpoint]bool // nil coalesces every endpoint
+
+	mu    sync.Mutex
+	calls map[string]*flight
+}
+
+// flight is an upstream call shared by its waiters
+type flight struct {
+	done    chan struct{}
+	value   reflect.Value // Pointer to the shared response
+	body    []byte        // value as JSON, or nil if it would not marshal
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// copyTo gives responseObj its own copy of the shared response. A JSON round
+// trip keeps callers from sharing slices and maps; responses that do not
+// round trip are copied shallowly.
+func (f *flight) copyTo(responseObj interface{}) error {
+	if f.body != nil {
+		return json.Unmarshal(f.body, responseObj)
+	}
+	reflect.ValueOf(responseObj).Elem().Set(f.value.Elem())
+	return nil
+}
+
+func (g *flightGroup) coalesces(e Endpoint) bool {
+	return g.endpoints == nil || g.endpoints[e]
+}
+
+// sendShared makes a JSON request, joining an identical request already in
+// flight when coalescing is enabled for endpoint
+func (o *OLAMap) sendShared(ctx context.Context, endpoint Endpoint, method, apiURL string, cred Credential, responseObj interface{}) error {
+	g := o.flights
+	responseType := reflect.TypeOf(responseObj)
+	if g == nil || !g.coalesces(endpoint) || responseType == nil || responseType.Kind() != reflect.Pointer {
+		return o.sendAuthorized(ctx, endpoint, method, apiURL, cred, responseObj)
+	}
+
+	key := method + " " + withAPIKey(apiURL, cred) + " " + cred.AccessToken + " " + responseType.String()
+
+	g.mu.Lock()
+	f, ok := g.calls[key]
+	if !ok {
+		upstream, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		f = &flight{done: make(chan struct{}), value: reflect.New(responseType.Elem()), cancel: cancel}
+		g.calls[key] = f
+		go g.run(f, key, func() error {
+			return o.sendAuthorized(upstream, endpoint, method, apiURL, cred, f.value.Interface())
+		})
+	}
+	f.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-f.done:
+		if f.err != nil {
+			return f.err
+		}
+		return f.copyTo(responseObj)
+	case <-ctx.Done():
+		g.leave(f, key)
+		return ctx.Err()
+	}
+}
+
+func (g *flightGroup) run(f *flight, key string, call func() error) {
+	f.err = call()
+	f.cancel()
+	if f.err == nil {
+		if body, err := json.Marshal(f.value.Interface()); err == nil {
+			f.body = body
+		}
+	}
+
+	g.mu.Lock()
+	if g.calls[key] == f {
+		delete(g.calls, key)
+	}
+	g.mu.Unlock()
+
+	close(f.done)
+}
+
+// leave drops a waiter, canceling the upstream call once none remain
+func (g *flightGroup) leave(f *flight, key string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	f.waiters--
+	if f.waiters == 0 {
+		f.cancel()
+		if g.calls[key] == f {
+			delete(g.calls, key)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package golamap
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// blockingService answers once release is closed, counting calls
+type blockingService struct {
+	body    string
+	err     error
+	release chan struct{}
+	calls   atomic.Int32
+}
+
+func (b *blockingService) SendOlaMapRequest(ctx context.Context, method, url, requestID, oauthToken string, responseObj interface{}) error {
+	b.calls.Add(1)
+	select {
+	case <-b.release:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	if b.err != nil {
+		return b.err
+	}
+	return json.Unmarshal([]byte(b.body), responseObj)
+}
+
+// typedService fills a *ForwardGecode directly, as a hand-written fake might
+type typedService struct {
+	release chan struct{}
+	calls   atomic.Int32
+}
+
+func (s *typedService) SendOlaMapRequest(ctx context.Context, method, url, requestID, oauthToken string, responseObj interface{}) error {
+	s.calls.Add(1)
+	<-s.release
+	geocode := responseObj.(*ForwardGecode)
+	geocode.Status = "typed"
+	geocode.GeocodingResults = []GeocodingResult{{FormattedAddress: "Mumbai"}}
+	return nil
+}
+
+// waitForWaiters waits until n callers are waiting on flights
+func waitForWaiters(t *testing.T, olaMap *OLAMap, n int) {
+	assert.Eventually(t, func() bool {
+		olaMap.flights.mu.Lock()
+		defer olaMap.flights.mu.Unlock()
+		waiters := 0
+		for _, f := range olaMap.flights.calls {
+			waiters += f.waiters
+		}
+		return waiters == n
+	}, time.Second, time.Millisecond)
+}
+
+func TestWithRequestCoalescing(t *testing.T) {
+	t.Run("identical calls share one request", func(t *testing.T) {
+		mocking := &blockingService{body: GeoCodeResponse, release: make(chan struct{})}
+		olaMap := NewClient(WithHttpService(mocking), WithAPIKey("mock-key"), WithRequestCoalescing())
+
+		results := make([]ForwardGecode, 5)
+		var wg sync.WaitGroup
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				var err error
+				results[i], err = olaMap.GeoCode("mumbai", Bounds{}, "")
+				assert.Nil(t, err)
+			}(i)
+		}
+		waitForWaiters(t, olaMap, len(results))
+		close(mocking.release)
+		wg.Wait()
+
+		assert.Equal(t, int32(1), mocking.calls.Load())
+		for _, result := range results {
+			assert.Equal(t, results[0], result)
+			assert.NotEmpty(t, result.GeocodingResults)
+		}
+		assert.Empty(t, olaMap.flights.calls)
+	})
+	t.Run("errors are shared", func(t *testing.T) {
+		mocking := &blockingService{err: errors.New("mock failure"), release: make(chan struct{})}
+		olaMap := NewClient(WithHttpService(mocking), WithAPIKey("mock-key"), WithRequestCoalescing())
+
+		var wg sync.WaitGroup
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := olaMap.GeoCode("mumbai", Bounds{}, "")
+				assert.EqualError(t, err, "failed to send request to Olamaps API: mock failure")
+			}()
+		}
+		waitForWaiters(t, olaMap, 3)
+		close(mocking.release)
+		wg.Wait()
+		assert.Equal(t, int32(1), mocking.calls.Load())
+	})
+	t.Run("different requests are not shared", func(t *testing.T) {
+		mocking := &blockingService{body: GeoCodeResponse, release: make(chan struct{})}
+		olaMap := NewClient(WithHttpService(mocking), WithAPIKey("mock-key"), WithRequestCoalescing())
+
+		var wg sync.WaitGroup
+		for _, address := range []string{"mumbai", "delhi"} {
+			wg.Add(1)
+			go func(address string) {
+				defer wg.Done()
+				_, err := olaMap.GeoCode(address, Bounds{}, "")
+				assert.Nil(t, err)
+			}(address)
+		}
+		waitForWaiters(t, olaMap, 2)
+		close(mocking.release)
+		wg.Wait()
+		assert.Equal(t, int32(2), mocking.calls.Load())
+	})
+	t.Run("services receive the caller's response type", func(t *testing.T) {
+		mocking := &MockStruct{}
+		olaMap := NewClient(WithHttpService(mocking), WithToken("mockToken"), WithRequestCoalescing())
+		_, err := olaMap.GeoCode("mumbai", Bounds{}, "")
+		assert.Nil(t, err)
+		assert.Equal(t, GeoCodeResponse, mocking.MockBody)
+
+		typed := &typedService{release: make(chan struct{})}
+		olaMap = NewClient(WithHttpService(typed), WithToken("mockToken"), WithRequestCoalescing())
+		results := make([]ForwardGecode, 3)
+		var wg sync.WaitGroup
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				var err error
+				results[i], err = olaMap.GeoCode("mumbai", Bounds{}, "")
+				assert.Nil(t, err)
+			}(i)
+		}
+		waitForWaiters(t, olaMap, len(results))
+		close(typed.release)
+		wg.Wait()
+
+		assert.Equal(t, int32(1), typed.calls.Load())
+		for _, result := range results {
+			assert.Equal(t, "typed", result.Status)
+			assert.Len(t, result.GeocodingResults, 1)
+		}
+		results[0].GeocodingResults[0].FormattedAddress = "changed"
+		assert.NotEqual(t, "changed", results[1].GeocodingResults[0].FormattedAddress, "callers get their own copy")
+	})
+	t.Run("only configured endpoints", func(t *testing.T) {
+		mocking := &blockingService{body: GeoCodeResponse, release: make(chan struct{})}
+		close(mocking.release)
+		olaMap := NewClient(WithHttpService(mocking), WithAPIKey("mock-key"), WithRequestCoalescing(EndpointReverseGeocode))
+
+		_, err := olaMap.GeoCode("mumbai", Bounds{}, "")
+		assert.Nil(t, err)
+		assert.Empty(t, olaMap.flights.calls)
+		assert.True(t, olaMap.flights.coalesces(EndpointReverseGeocode))
+		assert.False(t, olaMap.flights.coalesces(EndpointGeoCode))
+	})
+	t.Run("a canceled caller leaves the others waiting", func(t *testing.T) {
+		mocking := &blockingService{body: GeoCodeResponse, release: make(chan struct{})}
+		olaMap := NewClient(WithHttpService(mocking), WithAPIKey("mock-key"), WithRequestCoalescing())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		canceled := make(chan error)
+		go func() {
+			_, err := olaMap.GeoCodeWithContext(ctx, "mumbai", Bounds{}, "")
+			canceled <- err
+		}()
+		waitForWaiters(t, olaMap, 1)
+
+		done := make(chan error)
+		go func() {
+			_, err := olaMap.GeoCode("mumbai", Bounds{}, "")
+			done <- err
+		}()
+		waitForWaiters(t, olaMap, 2)
+
+		cancel()
+		assert.ErrorIs(t, <-canceled, context.Canceled)
+		close(mocking.release)
+		assert.Nil(t, <-done)
+		assert.Equal(t, int32(1), mocking.calls.Load())
+	})
+	t.Run("the request is canceled when every caller leaves", func(t *testing.T) {
+		mocking := &blockingService{body: GeoCodeResponse, release: make(chan struct{})}
+		olaMap := NewClient(WithHttpService(mocking), WithAPIKey("mock-key"), WithRequestCoalescing())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		canceled := make(chan error)
+		go func() {
+			_, err := olaMap.GeoCodeWithContext(ctx, "mumbai", Bounds{}, "")
+			canceled <- err
+		}()
+		waitForWaiters(t, olaMap, 1)
+		cancel()
+		assert.ErrorIs(t, <-canceled, context.Canceled)
+
+		olaMap.flights.mu.Lock()
+		assert.Empty(t, olaMap.flights.calls)
+		olaMap.flights.mu.Unlock()
+	})
+}
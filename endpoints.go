@@ -1,21 +1,124 @@
 package golamap
 
-var (
-	TokenURL                 = "https://account.olamaps.io/realms/olamaps/protocol/openid-connect/token"
-	DirectionsURL            = "https://api.olamaps.io/routing/v1/directions?origin=%s&destination=%s"
-	PlaceAutoCompleteURL     = "https://api.olamaps.io/places/v1/autocomplete?input=%s"
-	GeoCodeURL               = "https://api.olamaps.io/places/v1/geocode?address=%s&bounds=%s&language=%s"
-	ReverseGeocodeURL        = "https://api.olamaps.io/places/v1/reverse-geocode?latlng=%s"
-	DistanceMatrixURL        = "https://api.olamaps.io/routing/v1/distanceMatrix?origins=%s&destinations=%s"
-	ArrayOfDataURL           = "https://api.olamaps.io/tiles/vector/v1/data/%s.json"
-	StyleDetailsURL          = "https://api.olamaps.io/tiles/vector/v1/styles/%s/style.json"
-	MapStyleURL              = "https://api.olamaps.io/tiles/vector/v1/styles.json"
-	PlaceDetailURL           = "https://api.olamaps.io/places/v1/details?place_id=%v"
-	NearBySearchURL          = "https://api.olamaps.io/places/v1/nearbysearch?layers=%s&location=%s&types=%s&radius=%s&strictbounds=%s&withCentroid=%s&limit=%s"
-	TextSearchURL            = "https://api.olamaps.io/places/v1/textsearch?input=%s&location=%s&radius=%s&types=%s&size=%s"
-	SnapToRoadURL            = "https://api.olamaps.io/routing/v1/snapToRoad?%s"
-	NearestRoadsURL          = "https://api.olamaps.io/routing/v1/nearestRoads?points=%s&radius=%s"
-	StaticMapImageCenterURL  = "https://api.olamaps.io/tiles/v1/styles/%s/static/%f,%f,%d/%dx%d.%s"
-	StaticMapImageBoundedURL = "https://api.olamaps.io/tiles/v1/styles/%s/static/%f,%f,%f,%f/%dx%d.%s"
-	StaticMapImageURL        = "https://api.olamaps.io/tiles/v1/styles/%s/static/auto/%dx%d.%s"
+import (
+	"context"
+	"fmt"
+	"sort"
 )
+
+// Default base URLs, overridable per APIFamily with WithBaseURL
+const (
+	DefaultAuthBaseURL = "https://account.olamaps.io"
+	DefaultAPIBaseURL  = "https://api.olamaps.io"
+)
+
+// APIFamily is an Olamaps product whose endpoints share a base URL
+type APIFamily string
+
+const (
+	APIAuth    APIFamily = "auth"
+	APIRouting APIFamily = "routing"
+	APIPlaces  APIFamily = "places"
+	APITiles   APIFamily = "tiles"
+)
+
+// Endpoint identifies a single Olamaps API operation
+type Endpoint string
+
+const (
+	EndpointToken                 Endpoint = "token"
+	EndpointDirections            Endpoint = "directions"
+	EndpointPlaceAutoComplete     Endpoint = "autocomplete"
+	EndpointGeoCode               Endpoint = "geocode"
+	EndpointReverseGeocode        Endpoint = "reverse-geocode"
+	EndpointDistanceMatrix        Endpoint = "distanceMatrix"
+	EndpointArrayOfData           Endpoint = "data"
+	EndpointStyleDetails          Endpoint = "style"
+	EndpointMapStyle              Endpoint = "styles"
+	EndpointPlaceDetail           Endpoint = "details"
+	EndpointNearBySearch          Endpoint = "nearbysearch"
+	EndpointTextSearch            Endpoint = "textsearch"
+	EndpointSnapToRoad            Endpoint = "snapToRoad"
+	EndpointNearestRoads          Endpoint = "nearestRoads"
+	EndpointStaticMapImageCenter  Endpoint = "static-center"
+	EndpointStaticMapImageBounded Endpoint = "static-bounded"
+	EndpointStaticMapImage        Endpoint = "static-auto"
+)
+
+type endpointSpec struct {
+	family APIFamily
+	path   string // fmt template relative to the family base URL
+}
+
+var endpoints = map[Endpoint]endpointSpec{
+	EndpointToken:                 {APIAuth, "/realms/olamaps/protocol/openid-connect/token"},
+	EndpointDirections:            {APIRouting, "/routing/v1/directions?origin=%s&destination=%s"},
+	EndpointPlaceAutoComplete:     {APIPlaces, "/places/v1/autocomplete?input=%s"},
+	EndpointGeoCode:               {APIPlaces, "/places/v1/geocode?address=%s&bounds=%s&language=%s"},
+	EndpointReverseGeocode:        {APIPlaces, "/places/v1/reverse-geocode?latlng=%s"},
+	EndpointDistanceMatrix:        {APIRouting, "/routing/v1/distanceMatrix?origins=%s&destinations=%s"},
+	EndpointArrayOfData:           {APITiles, "/tiles/vector/v1/data/%s.json"},
+	EndpointStyleDetails:          {APITiles, "/tiles/vector/v1/styles/%s/style.json"},
+	EndpointMapStyle:              {APITiles, "/tiles/vector/v1/styles.json"},
+	EndpointPlaceDetail:           {APIPlaces, "/places/v1/details?place_id=%v"},
+	EndpointNearBySearch:          {APIPlaces, "/places/v1/nearbysearch?layers=%s&location=%s&types=%s&radius=%s&strictbounds=%s&withCentroid=%s&limit=%s"},
+	EndpointTextSearch:            {APIPlaces, "/places/v1/textsearch?input=%s&location=%s&radius=%s&types=%s&size=%s"},
+	EndpointSnapToRoad:            {APIRouting, "/routing/v1/snapToRoad?%s"},
+	EndpointNearestRoads:          {APIRouting, "/routing/v1/nearestRoads?points=%s&radius=%s"},
+	EndpointStaticMapImageCenter:  {APITiles, "/tiles/v1/styles/%s/static/%f,%f,%d/%dx%d.%s"},
+	EndpointStaticMapImageBounded: {APITiles, "/tiles/v1/styles/%s/static/%f,%f,%f,%f/%dx%d.%s"},
+	EndpointStaticMapImage:        {APITiles, "/tiles/v1/styles/%s/static/auto/%dx%d.%s"},
+}
+
+// Family returns the APIFamily the endpoint belongs to
+func (e Endpoint) Family() APIFamily {
+	return endpoints[e].family
+}
+
+// Path returns the fmt template of the endpoint's path and query, relative
+// to the base URL of its family
+func (e Endpoint) Path() string {
+	return endpoints[e].path
+}
+
+// Endpoints returns every known Endpoint, sorted by name
+func Endpoints() []Endpoint {
+	all := make([]Endpoint, 0, len(endpoints))
+	for e := range endpoints {
+		all = append(all, e)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i] < all[j] })
+	return all
+}
+
+// baseURL returns the base URL configured for family
+func (o *OLAMap) baseURL(family APIFamily) string {
+	if baseURL, ok := o.baseURLs[family]; ok {
+		return baseURL
+	}
+	if family == APIAuth {
+		return DefaultAuthBaseURL
+	}
+	return DefaultAPIBaseURL
+}
+
+// endpointURL builds the full URL for e, formatting its path with args
+func (o *OLAMap) endpointURL(e Endpoint, args ...interface{}) string {
+	spec := endpoints[e]
+	return o.baseURL(spec.family) + fmt.Sprintf(spec.path, args...)
+}
+
+type endpointKey struct{}
+
+// withEndpoint tags ctx with the endpoint a request is made for
+func withEndpoint(ctx context.Context, e Endpoint) context.Context {
+	return context.WithValue(ctx, endpointKey{}, e)
+}
+
+// EndpointFromContext returns the endpoint an outgoing request was made for.
+// It lets transports and middleware tell requests apart, e.g. via
+// EndpointFromContext(req.Context()).
+func EndpointFromContext(ctx context.Context) (Endpoint, bool) {
+	e, ok := ctx.Value(endpointKey{}).(Endpoint)
+	return e, ok
+}
@@ -0,0 +1,113 @@
+package golamap
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Sentinel errors, matched with errors.Is
+var (
+	// ErrValidation is returned when request parameters are missing or malformed
+	ErrValidation = errors.New("invalid request parameters")
+	// ErrUnauthorized is returned when no credentials are configured or the
+	// Olamaps API rejects them
+	ErrUnauthorized = errors.New("Olamaps API rejected the OAuth token")
+	// ErrRateLimited is returned when the Olamaps API throttles a request
+	ErrRateLimited = errors.New("Olamaps API rate limit exceeded")
+	// ErrCircuitOpen is returned without calling the Olamaps API while the
+	// circuit breaker of the endpoint's APIFamily is open
+	ErrCircuitOpen = errors.New("Olamaps API circuit breaker is open")
+)
+
+// kindError keeps its own message while matching a sentinel with errors.Is
+type kindError struct {
+	msg  string
+	kind error
+}
+
+func (e *kindError) Error() string { return e.msg }
+func (e *kindError) Unwrap() error { return e.kind }
+
+func validationError(msg string) error {
+	return &kindError{msg: msg, kind: ErrValidation}
+}
+
+func unauthorizedError(msg string) error {
+	return &kindError{msg: msg, kind: ErrUnauthorized}
+}
+
+// APIError is returned when the Olamaps API answers with a non-2xx status.
+// 401 and 403 match ErrUnauthorized and 429 matches ErrRateLimited.
+type APIError struct {
+	StatusCode    int    // HTTP status code
+	Status        string // Ola "status" field, e.g. REQUEST_DENIED
+	ErrorMessage  string // Ola "error_message" (or "message") field
+	RequestID     string // X-Request-Id sent with the request
+	CorrelationID string // X-Correlation-Id of the request or response
+	Body          []byte // Raw response body
+	Err           error  // Underlying cause, if any
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("Olamaps API error - statuscode %d", e.StatusCode)
+	if e.Status != "" {
+		msg += " " + e.Status
+	}
+	if e.ErrorMessage != "" {
+		msg += ": " + e.ErrorMessage
+	}
+	return msg
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// newAPIError builds an APIError from a non-2xx response, consuming its body
+func newAPIError(resp *http.Response) *APIError {
+	apiErr := &APIError{
+		StatusCode:    resp.StatusCode,
+		CorrelationID: resp.Header.Get("X-Correlation-Id"),
+	}
+
+	if resp.Request != nil {
+		apiErr.RequestID = resp.Request.Header.Get("X-Request-Id")
+		if apiErr.CorrelationID == "" {
+			apiErr.CorrelationID = resp.Request.Header.Get("X-Correlation-Id")
+		}
+	}
+
+	switch resp.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		apiErr.Err = ErrUnauthorized
+	case http.StatusTooManyRequests:
+		apiErr.Err = ErrRateLimited
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		if apiErr.Err == nil {
+			apiErr.Err = err
+		}
+		return apiErr
+	}
+	apiErr.Body = body
+
+	var payload struct {
+		Status       string `json:"status"`
+		ErrorMessage string `json:"error_message"`
+		Message      string `json:"message"`
+	}
+	if json.Unmarshal(body, &payload) == nil {
+		apiErr.Status = payload.Status
+		apiErr.ErrorMessage = payload.ErrorMessage
+		if apiErr.ErrorMessage == "" {
+			apiErr.ErrorMessage = payload.Message
+		}
+	}
+
+	return apiErr
+}
@@ -0,0 +1,70 @@
+package golamap
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Correlation-Id", "mock-correlation-id")
+		switch r.URL.Query().Get("input") {
+		case "denied":
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"status":"REQUEST_DENIED","error_message":"Invalid API key"}`))
+		case "throttled":
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"message":"Too many requests"}`))
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte(`<html>bad gateway</html>`))
+		}
+	}))
+	defer server.Close()
+	olaMap := NewClient(WithBaseURL(APIPlaces, server.URL), WithAPIKey("mock-key"), WithRequestID("mock-request-id"))
+
+	t.Run("ola error payload", func(t *testing.T) {
+		_, err := olaMap.PlaceAutoComplete("denied")
+		var apiErr *APIError
+		assert.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+		assert.Equal(t, "REQUEST_DENIED", apiErr.Status)
+		assert.Equal(t, "Invalid API key", apiErr.ErrorMessage)
+		assert.Equal(t, "mock-request-id", apiErr.RequestID)
+		assert.Equal(t, "mock-correlation-id", apiErr.CorrelationID)
+		assert.ErrorIs(t, err, ErrUnauthorized)
+		assert.EqualError(t, err, "failed to send request to Olamaps API: Olamaps API error - statuscode 403 REQUEST_DENIED: Invalid API key")
+	})
+	t.Run("rate limited", func(t *testing.T) {
+		_, err := olaMap.PlaceAutoComplete("throttled")
+		var apiErr *APIError
+		assert.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, "Too many requests", apiErr.ErrorMessage)
+		assert.ErrorIs(t, err, ErrRateLimited)
+	})
+	t.Run("non json body", func(t *testing.T) {
+		_, err := olaMap.PlaceAutoComplete("mock-input")
+		var apiErr *APIError
+		assert.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, http.StatusBadGateway, apiErr.StatusCode)
+		assert.Equal(t, []byte(`<html>bad gateway</html>`), apiErr.Body)
+		assert.False(t, errors.Is(err, ErrUnauthorized))
+	})
+}
+
+func TestSentinelErrors(t *testing.T) {
+	t.Run("validation", func(t *testing.T) {
+		_, err := (&OLAMap{}).GetDirections(LatLng{}, LatLng{})
+		assert.ErrorIs(t, err, ErrValidation)
+		assert.EqualError(t, err, "Missing required query parameters: 'origin' and/or 'destination'")
+	})
+	t.Run("missing credentials", func(t *testing.T) {
+		_, err := (&OLAMap{}).GetDirections(mockOrigin, mockDestination)
+		assert.ErrorIs(t, err, ErrUnauthorized)
+		assert.EqualError(t, err, "Invalid OAuth token")
+	})
+}
@@ -0,0 +1,224 @@
+package golamap
+
+import (
+	"errors"
+	"math"
+)
+
+// earthRadius is the mean radius of the earth in meters
+const earthRadius = 6371008.8
+
+// WGS-84 ellipsoid, used by VincentyDistance
+const (
+	wgs84A = 6378137.0
+	wgs84F = 1 / 298.257223563
+	wgs84B = wgs84A * (1 - wgs84F)
+)
+
+// ErrNoConvergence is returned by VincentyDistance for nearly antipodal
+// points, where the formula does not converge
+var ErrNoConvergence = errors.New("vincenty formula failed to converge")
+
+func radians(deg float64) float64 { return deg * math.Pi / 180 }
+func degrees(rad float64) float64 { return rad * 180 / math.Pi }
+
+// normalizeLng wraps a longitude into [-180, 180)
+func normalizeLng(lng float64) float64 {
+	return math.Mod(math.Mod(lng+180, 360)+360, 360) - 180
+}
+
+// Distance returns the great-circle (haversine) distance to other in meters
+func (l Location) Distance(other Location) float64 {
+	lat1, lat2 := radians(l.Lat), radians(other.Lat)
+	dLat := lat2 - lat1
+	dLng := radians(other.Lng - l.Lng)
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// VincentyDistance returns the distance to other in meters on the WGS-84
+// ellipsoid, accurate to within a millimeter. It returns ErrNoConvergence
+// for nearly antipodal points.
+func (l Location) VincentyDistance(other Location) (float64, error) {
+	L := radians(other.Lng - l.Lng)
+	U1 := math.Atan((1 - wgs84F) * math.Tan(radians(l.Lat)))
+	U2 := math.Atan((1 - wgs84F) * math.Tan(radians(other.Lat)))
+	sinU1, cosU1 := math.Sincos(U1)
+	sinU2, cosU2 := math.Sincos(U2)
+
+	lambda := L
+	for i := 0; i < 200; i++ {
+		sinLambda, cosLambda := math.Sincos(lambda)
+		sinSigma := math.Sqrt((cosU2*sinLambda)*(cosU2*sinLambda) +
+			(cosU1*sinU2-sinU1*cosU2*cosLambda)*(cosU1*sinU2-sinU1*cosU2*cosLambda))
+		if sinSigma == 0 {
+			return 0, nil // coincident points
+		}
+		cosSigma := sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma := math.Atan2(sinSigma, cosSigma)
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cosSqAlpha := 1 - sinAlpha*sinAlpha
+		cos2SigmaM := 0.0
+		if cosSqAlpha != 0 {
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cosSqAlpha
+		}
+		C := wgs84F / 16 * cosSqAlpha * (4 + wgs84F*(4-3*cosSqAlpha))
+		prev := lambda
+		lambda = L + (1-C)*wgs84F*sinAlpha*(sigma+C*sinSigma*(cos2SigmaM+C*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+
+		if math.Abs(lambda-prev) < 1e-12 {
+			uSq := cosSqAlpha * (wgs84A*wgs84A - wgs84B*wgs84B) / (wgs84B * wgs84B)
+			A := 1 + uSq/16384*(4096+uSq*(-768+uSq*(320-175*uSq)))
+			B := uSq / 1024 * (256 + uSq*(-128+uSq*(74-47*uSq)))
+			deltaSigma := B * sinSigma * (cos2SigmaM + B/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+				B/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+			return wgs84B * A * (sigma - deltaSigma), nil
+		}
+	}
+
+	return 0, ErrNoConvergence
+}
+
+// InitialBearing returns the bearing in degrees clockwise from north, in
+// [0, 360), at which the great-circle path to other leaves l
+func (l Location) InitialBearing(other Location) float64 {
+	lat1, lat2 := radians(l.Lat), radians(other.Lat)
+	dLng := radians(other.Lng - l.Lng)
+
+	y := math.Sin(dLng) * math.Cos(lat2)
+	x := math.Cos(lat1)*math.Sin(lat2) - math.Sin(lat1)*math.Cos(lat2)*math.Cos(dLng)
+	return math.Mod(degrees(math.Atan2(y, x))+360, 360)
+}
+
+// FinalBearing returns the bearing in degrees, in [0, 360), at which the
+// great-circle path from l arrives at other
+func (l Location) FinalBearing(other Location) float64 {
+	return math.Mod(other.InitialBearing(l)+180, 360)
+}
+
+// Destination returns the point reached by travelling distance meters from
+// l along the great circle with the given initial bearing in degrees
+func (l Location) Destination(bearing, distance float64) Location {
+	lat1, lng1 := radians(l.Lat), radians(l.Lng)
+	theta := radians(bearing)
+	delta := distance / earthRadius
+
+	lat2 := math.Asin(math.Sin(lat1)*math.Cos(delta) + math.Cos(lat1)*math.Sin(delta)*math.Cos(theta))
+	lng2 := lng1 + math.Atan2(math.Sin(theta)*math.Sin(delta)*math.Cos(lat1), math.Cos(delta)-math.Sin(lat1)*math.Sin(lat2))
+
+	return Location{Lat: degrees(lat2), Lng: normalizeLng(degrees(lng2))}
+}
+
+// Midpoint returns the point halfway along the great-circle path to other
+func (l Location) Midpoint(other Location) Location {
+	lat1, lng1 := radians(l.Lat), radians(l.Lng)
+	lat2 := radians(other.Lat)
+	dLng := radians(other.Lng - l.Lng)
+
+	bx := math.Cos(lat2) * math.Cos(dLng)
+	by := math.Cos(lat2) * math.Sin(dLng)
+	lat := math.Atan2(math.Sin(lat1)+math.Sin(lat2), math.Sqrt((math.Cos(lat1)+bx)*(math.Cos(lat1)+bx)+by*by))
+	lng := lng1 + math.Atan2(by, math.Cos(lat1)+bx)
+
+	return Location{Lat: degrees(lat), Lng: normalizeLng(degrees(lng))}
+}
+
+// BoundingBox returns the smallest Bounds containing every point within
+// radius meters of l. Near the poles the box spans every longitude; across
+// the antimeridian its Southwest.Lng is greater than its Northeast.Lng.
+func (l Location) BoundingBox(radius float64) Bounds {
+	delta := degrees(radius / earthRadius)
+	south, north := l.Lat-delta, l.Lat+delta
+	if south <= -90 || north >= 90 {
+		return Bounds{
+			Southwest: Location{Lat: math.Max(south, -90), Lng: -180},
+			Northeast: Location{Lat: math.Min(north, 90), Lng: 180},
+		}
+	}
+
+	dLng := degrees(math.Asin(math.Sin(radius/earthRadius) / math.Cos(radians(l.Lat))))
+	return Bounds{
+		Southwest: Location{Lat: south, Lng: normalizeLng(l.Lng - dLng)},
+		Northeast: Location{Lat: north, Lng: normalizeLng(l.Lng + dLng)},
+	}
+}
+
+// Contains reports whether l lies within b, including on its edges
+func (b Bounds) Contains(l Location) bool {
+	if l.Lat < b.Southwest.Lat || l.Lat > b.Northeast.Lat {
+		return false
+	}
+	if b.Southwest.Lng <= b.Northeast.Lng {
+		return l.Lng >= b.Southwest.Lng && l.Lng <= b.Northeast.Lng
+	}
+	// The bounds cross the antimeridian
+	return l.Lng >= b.Southwest.Lng || l.Lng <= b.Northeast.Lng
+}
+
+// Extend returns b grown to contain l, eastward or westward, whichever
+// is narrower, so the result may cross the antimeridian. The zero Bounds is
+// the single point (0,0), not an empty set; start from BoundsOf instead.
+func (b Bounds) Extend(l Location) Bounds {
+	return b.Union(Bounds{Southwest: l, Northeast: l})
+}
+
+// Union returns the smallest Bounds containing both b and other. Either
+// may cross the antimeridian, and so may the result.
+func (b Bounds) Union(other Bounds) Bounds {
+	west, east := -180.0, 180.0
+	span := math.Inf(1)
+	for _, arc := range [][2]float64{
+		{b.Southwest.Lng, b.Northeast.Lng},
+		{other.Southwest.Lng, other.Northeast.Lng},
+		{b.Southwest.Lng, other.Northeast.Lng},
+		{other.Southwest.Lng, b.Northeast.Lng},
+	} {
+		if s := lngSpan(arc[0], arc[1]); s < span &&
+			lngCovers(arc[0], arc[1], b.Southwest.Lng, b.Northeast.Lng) &&
+			lngCovers(arc[0], arc[1], other.Southwest.Lng, other.Northeast.Lng) {
+			west, east, span = arc[0], arc[1], s
+		}
+	}
+
+	return Bounds{
+		Southwest: Location{Lat: math.Min(b.Southwest.Lat, other.Southwest.Lat), Lng: west},
+		Northeast: Location{Lat: math.Max(b.Northeast.Lat, other.Northeast.Lat), Lng: east},
+	}
+}
+
+// lngSpan returns the degrees of longitude covered going east from west to
+// east
+func lngSpan(west, east float64) float64 {
+	if west <= east {
+		return east - west
+	}
+	return east - west + 360
+}
+
+// lngCovers reports whether the longitudes going east from west to east
+// include all of those from innerWest to innerEast
+func lngCovers(west, east, innerWest, innerEast float64) bool {
+	span := lngSpan(west, east)
+	if span >= 360 {
+		return true
+	}
+	offset := lngSpan(west, innerWest)
+	if offset >= 360 {
+		offset = 0
+	}
+	return offset+lngSpan(innerWest, innerEast) <= span
+}
+
+// BoundsOf returns the smallest Bounds containing every location, or the
+// zero Bounds if there are none
+func BoundsOf(locations []Location) Bounds {
+	if len(locations) == 0 {
+		return Bounds{}
+	}
+	b := Bounds{Southwest: locations[0], Northeast: locations[0]}
+	for _, l := range locations[1:] {
+		b = b.Extend(l)
+	}
+	return b
+}
@@ -0,0 +1,118 @@
+package golamap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocationGeodesy(t *testing.T) {
+	bengaluru := Location{Lat: 12.9716, Lng: 77.5946}
+	mumbai := Location{Lat: 19.0760, Lng: 72.8777}
+
+	t.Run("distance", func(t *testing.T) {
+		assert.InDelta(t, 845000, bengaluru.Distance(mumbai), 2000)
+		assert.Zero(t, bengaluru.Distance(bengaluru))
+	})
+	t.Run("vincenty distance", func(t *testing.T) {
+		// Flinders Peak to Buninyong, the reference case from Vincenty's paper
+		flinders := Location{Lat: -37.95103341666667, Lng: 144.42486788888888}
+		buninyong := Location{Lat: -37.65282113888889, Lng: 143.92649552777777}
+		distance, err := flinders.VincentyDistance(buninyong)
+		assert.Nil(t, err)
+		assert.InDelta(t, 54972.271, distance, 0.01)
+
+		distance, err = bengaluru.VincentyDistance(bengaluru)
+		assert.Nil(t, err)
+		assert.Zero(t, distance)
+
+		_, err = Location{Lat: 0, Lng: 0}.VincentyDistance(Location{Lat: 0.5, Lng: 179.7})
+		assert.ErrorIs(t, err, ErrNoConvergence)
+	})
+	t.Run("bearing", func(t *testing.T) {
+		assert.InDelta(t, 0, Location{}.InitialBearing(Location{Lat: 1}), 1e-9)
+		assert.InDelta(t, 90, Location{}.InitialBearing(Location{Lng: 1}), 1e-9)
+		baghdad, osaka := Location{Lat: 35, Lng: 45}, Location{Lat: 35, Lng: 135}
+		assert.InDelta(t, 60.16, baghdad.InitialBearing(osaka), 0.01)
+		assert.InDelta(t, 119.84, baghdad.FinalBearing(osaka), 0.01)
+	})
+	t.Run("destination", func(t *testing.T) {
+		bearing := bengaluru.InitialBearing(mumbai)
+		destination := bengaluru.Destination(bearing, bengaluru.Distance(mumbai))
+		assert.InDelta(t, mumbai.Lat, destination.Lat, 1e-6)
+		assert.InDelta(t, mumbai.Lng, destination.Lng, 1e-6)
+
+		wrapped := Location{Lng: 179.9}.Destination(90, 50000)
+		assert.Less(t, wrapped.Lng, -179.0)
+	})
+	t.Run("midpoint", func(t *testing.T) {
+		midpoint := bengaluru.Midpoint(mumbai)
+		assert.InDelta(t, bengaluru.Distance(midpoint), mumbai.Distance(midpoint), 1e-3)
+	})
+	t.Run("bounding box", func(t *testing.T) {
+		box := bengaluru.BoundingBox(1000)
+		assert.True(t, box.Contains(bengaluru))
+		assert.True(t, box.Contains(bengaluru.Destination(45, 999)))
+		assert.False(t, box.Contains(bengaluru.Destination(0, 1001)))
+		assert.InDelta(t, 1000, bengaluru.Distance(Location{Lat: box.Northeast.Lat, Lng: bengaluru.Lng}), 1e-6)
+
+		polar := Location{Lat: 89.99, Lng: 10}.BoundingBox(5000)
+		assert.Equal(t, -180.0, polar.Southwest.Lng)
+		assert.Equal(t, 90.0, polar.Northeast.Lat)
+
+		antimeridian := Location{Lat: 0, Lng: 179.99}.BoundingBox(5000)
+		assert.Greater(t, antimeridian.Southwest.Lng, antimeridian.Northeast.Lng)
+		assert.True(t, antimeridian.Contains(Location{Lat: 0, Lng: -179.99}))
+		assert.False(t, antimeridian.Contains(Location{Lat: 0, Lng: 0}))
+	})
+}
+
+func TestBounds(t *testing.T) {
+	t.Run("extend", func(t *testing.T) {
+		b := BoundsOf([]Location{{Lat: 12, Lng: 77}})
+		assert.Equal(t, Bounds{Southwest: Location{Lat: 12, Lng: 77}, Northeast: Location{Lat: 12, Lng: 77}}, b)
+		assert.Equal(t, Bounds{}, BoundsOf(nil))
+
+		b = b.Extend(Location{Lat: 13, Lng: 76})
+		assert.Equal(t, Bounds{Southwest: Location{Lat: 12, Lng: 76}, Northeast: Location{Lat: 13, Lng: 77}}, b)
+		assert.True(t, b.Contains(Location{Lat: 12.5, Lng: 76.5}))
+		assert.False(t, b.Contains(Location{Lat: 14, Lng: 76.5}))
+	})
+	t.Run("union", func(t *testing.T) {
+		a := BoundsOf([]Location{{Lat: 12, Lng: 77}, {Lat: 13, Lng: 78}})
+		b := BoundsOf([]Location{{Lat: 19, Lng: 72}, {Lat: 20, Lng: 73}})
+		assert.Equal(t, Bounds{Southwest: Location{Lat: 12, Lng: 72}, Northeast: Location{Lat: 20, Lng: 78}}, a.Union(b))
+		assert.Equal(t, a, a.Union(a))
+	})
+	t.Run("the origin is a location", func(t *testing.T) {
+		want := Bounds{Southwest: Location{Lat: 0, Lng: 0}, Northeast: Location{Lat: 5, Lng: 5}}
+		assert.Equal(t, want, BoundsOf([]Location{{Lat: 5, Lng: 5}, {Lat: 0, Lng: 0}}))
+		assert.Equal(t, want, BoundsOf([]Location{{Lat: 0, Lng: 0}, {Lat: 5, Lng: 5}}))
+		assert.Equal(t, want, Bounds{}.Extend(Location{Lat: 5, Lng: 5}))
+		assert.Equal(t, want, BoundsOf([]Location{{Lat: 5, Lng: 5}}).Union(Bounds{}))
+	})
+	t.Run("across the antimeridian", func(t *testing.T) {
+		wrapped := Location{Lat: 0, Lng: 179.99}.BoundingBox(5000)
+		extended := wrapped.Extend(Location{Lat: 0.1, Lng: -179.5})
+		assert.Equal(t, wrapped.Southwest.Lng, extended.Southwest.Lng)
+		assert.Equal(t, -179.5, extended.Northeast.Lng)
+		assert.True(t, extended.Contains(Location{Lat: 0, Lng: 180}))
+		assert.False(t, extended.Contains(Location{Lat: 0, Lng: 0}))
+
+		fiji := BoundsOf([]Location{{Lat: -16, Lng: 178}, {Lat: -17, Lng: -179}})
+		assert.Equal(t, Bounds{Southwest: Location{Lat: -17, Lng: 178}, Northeast: Location{Lat: -16, Lng: -179}}, fiji)
+
+		union := fiji.Union(BoundsOf([]Location{{Lat: -18, Lng: 175}, {Lat: -18, Lng: 176}}))
+		assert.Equal(t, Bounds{Southwest: Location{Lat: -18, Lng: 175}, Northeast: Location{Lat: -16, Lng: -179}}, union)
+		assert.Equal(t, union, union.Union(fiji))
+		assert.Equal(t, union, fiji.Union(union))
+
+		contained := fiji.Union(BoundsOf([]Location{{Lat: -16.5, Lng: 179}, {Lat: -16.5, Lng: 179.5}}))
+		assert.Equal(t, fiji, contained)
+
+		world := Bounds{Southwest: Location{Lat: 0, Lng: 0}, Northeast: Location{Lat: 1, Lng: 179}}.
+			Union(Bounds{Southwest: Location{Lat: 0, Lng: 178}, Northeast: Location{Lat: 1, Lng: 1}})
+		assert.Equal(t, -180.0, world.Southwest.Lng)
+		assert.Equal(t, 180.0, world.Northeast.Lng)
+	})
+}
@@ -0,0 +1,159 @@
+package golamap
+
+import (
+	"container/list"
+	"math"
+	"sync"
+	"time"
+)
+
+// ReverseGeocodeCache answers ReverseGeocode for points within Tolerance
+// meters of a point already looked up, which suits tracking workloads
+// where successive pings differ by a few meters. Points are bucketed on a
+// grid whose cells are Tolerance wide, and the nearest cached point in the
+// surrounding cells is used. It is safe to share between clients.
+type ReverseGeocodeCache struct {
+	tolerance float64
+	ttl       time.Duration
+	capacity  int
+	now       func() time.Time
+
+	mu     sync.Mutex
+	order  *list.List // Front is most recently used
+	cells  map[gridCell][]*list.Element
+	counts CacheCounts
+}
+
+type gridCell struct {
+	row, col int64
+}
+
+type geocodeEntry struct {
+	cell    gridCell
+	point   LatLng
+	result  ReverseGecode
+	expires time.Time
+}
+
+// NewReverseGeocodeCache returns a cache that reuses results for points up
+// to tolerance meters apart, for ttl, holding up to capacity results
+func NewReverseGeocodeCache(tolerance float64, ttl time.Duration, capacity int) *ReverseGeocodeCache {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &ReverseGeocodeCache{
+		tolerance: tolerance,
+		ttl:       ttl,
+		capacity:  capacity,
+		now:       time.Now,
+		order:     list.New(),
+		cells:     map[gridCell][]*list.Element{},
+	}
+}
+
+// WithReverseGeocodeCache answers ReverseGeocode from cache for nearby
+// points. It is consulted before, and independently of, WithCache.
+func WithReverseGeocodeCache(cache *ReverseGeocodeCache) Option {
+	return func(o *OLAMap) {
+		o.reverseGeocodeCache = cache
+	}
+}
+
+// Stats returns the cache's hits and misses so far
+func (c *ReverseGeocodeCache) Stats() CacheCounts {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.counts
+}
+
+// Len returns the number of cached results
+func (c *ReverseGeocodeCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// Get returns the result cached for the nearest point within the tolerance
+// of point
+func (c *ReverseGeocodeCache) Get(point LatLng) (ReverseGecode, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	var nearest *list.Element
+	nearestDistance := math.Inf(1)
+	center := c.cell(point)
+	for row := center.row - 1; row <= center.row+1; row++ {
+		col := c.col(row, point.Lng)
+		for dc := int64(-1); dc <= 1; dc++ {
+			for _, elem := range c.cells[gridCell{row, col + dc}] {
+				entry := elem.Value.(*geocodeEntry)
+				if !now.Before(entry.expires) {
+					continue
+				}
+				if distance := point.Distance(entry.point); distance <= c.tolerance && distance < nearestDistance {
+					nearest, nearestDistance = elem, distance
+				}
+			}
+		}
+	}
+
+	if nearest == nil {
+		c.counts.Misses++
+		return ReverseGecode{}, false
+	}
+	c.counts.Hits++
+	c.order.MoveToFront(nearest)
+	return nearest.Value.(*geocodeEntry).result, true
+}
+
+// Set caches result for point
+func (c *ReverseGeocodeCache) Set(point LatLng, result ReverseGecode) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cell := c.cell(point)
+	elem := c.order.PushFront(&geocodeEntry{cell: cell, point: point, result: result, expires: c.now().Add(c.ttl)})
+	c.cells[cell] = append(c.cells[cell], elem)
+
+	for c.order.Len() > c.capacity {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *ReverseGeocodeCache) remove(elem *list.Element) {
+	c.order.Remove(elem)
+	cell := elem.Value.(*geocodeEntry).cell
+	elems := c.cells[cell]
+	for i, e := range elems {
+		if e == elem {
+			elems = append(elems[:i], elems[i+1:]...)
+			break
+		}
+	}
+	if len(elems) == 0 {
+		delete(c.cells, cell)
+	} else {
+		c.cells[cell] = elems
+	}
+}
+
+// cellDegrees is the height of a grid cell in degrees of latitude
+func (c *ReverseGeocodeCache) cellDegrees() float64 {
+	return math.Max(degrees(c.tolerance/earthRadius), 1e-9)
+}
+
+func (c *ReverseGeocodeCache) cell(point LatLng) gridCell {
+	row := int64(math.Floor(point.Lat / c.cellDegrees()))
+	return gridCell{row, c.col(row, point.Lng)}
+}
+
+// col returns the column of lng in row. Cells are at least the tolerance
+// wide throughout their row, so a point within the tolerance of another is
+// always in the same or an adjacent cell.
+func (c *ReverseGeocodeCache) col(row int64, lng float64) int64 {
+	size := c.cellDegrees()
+	edge := math.Max(math.Abs(float64(row)*size), math.Abs(float64(row+1)*size))
+	width := size / math.Max(math.Cos(radians(math.Min(edge, 90))), 1e-6)
+	return int64(math.Floor(lng / width))
+}
@@ -0,0 +1,89 @@
+package golamap
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReverseGeocodeCache(t *testing.T) {
+	ping := LatLng{Lat: 12.931316, Lng: 77.616508}
+	result := ReverseGecode{Status: "ok", Results: []ReverseGeoResult{{Name: "cached"}}}
+
+	t.Run("nearby points hit", func(t *testing.T) {
+		cache := NewReverseGeocodeCache(25, time.Hour, 100)
+		cache.Set(ping, result)
+
+		cached, ok := cache.Get(ping.Destination(45, 10))
+		assert.True(t, ok)
+		assert.Equal(t, result, cached)
+
+		_, ok = cache.Get(ping.Destination(45, 30))
+		assert.False(t, ok)
+		assert.Equal(t, CacheCounts{Hits: 1, Misses: 1}, cache.Stats())
+	})
+	t.Run("nearest point wins", func(t *testing.T) {
+		cache := NewReverseGeocodeCache(25, time.Hour, 100)
+		cache.Set(ping, result)
+		near := ping.Destination(90, 20)
+		cache.Set(near, ReverseGecode{Status: "near"})
+
+		cached, ok := cache.Get(ping.Destination(90, 15))
+		assert.True(t, ok)
+		assert.Equal(t, "near", cached.Status)
+	})
+	t.Run("points within tolerance always hit", func(t *testing.T) {
+		random := rand.New(rand.NewSource(1))
+		for i := 0; i < 2000; i++ {
+			tolerance := 5 + random.Float64()*100
+			cache := NewReverseGeocodeCache(tolerance, time.Hour, 10)
+			point := LatLng{Lat: random.Float64()*170 - 85, Lng: random.Float64()*358 - 179}
+			cache.Set(point, result)
+
+			nearby := point.Destination(random.Float64()*360, random.Float64()*tolerance*0.999)
+			_, ok := cache.Get(nearby)
+			assert.True(t, ok, "%v and %v are %.2fm apart, tolerance %.2fm", point, nearby, point.Distance(nearby), tolerance)
+		}
+	})
+	t.Run("expires", func(t *testing.T) {
+		now := time.Now()
+		cache := NewReverseGeocodeCache(25, time.Minute, 100)
+		cache.now = func() time.Time { return now }
+		cache.Set(ping, result)
+
+		now = now.Add(time.Minute)
+		_, ok := cache.Get(ping)
+		assert.False(t, ok)
+	})
+	t.Run("evicts least recently used", func(t *testing.T) {
+		cache := NewReverseGeocodeCache(25, time.Hour, 2)
+		a, b, c := ping, ping.Destination(0, 1000), ping.Destination(0, 2000)
+		cache.Set(a, result)
+		cache.Set(b, result)
+		cache.Get(a)
+		cache.Set(c, result)
+
+		assert.Equal(t, 2, cache.Len())
+		_, ok := cache.Get(b)
+		assert.False(t, ok)
+		_, ok = cache.Get(a)
+		assert.True(t, ok)
+	})
+	t.Run("cuts API calls", func(t *testing.T) {
+		mocking := &fixtureService{body: ReverseGeocodeResponse}
+		cache := NewReverseGeocodeCache(25, time.Hour, 100)
+		olaMap := NewClient(WithHttpService(mocking), WithAPIKey("mock-key"), WithReverseGeocodeCache(cache))
+
+		for i := 0; i < 10; i++ {
+			_, err := olaMap.ReverseGeocode(ping.Destination(float64(i*36), float64(i)))
+			assert.Nil(t, err)
+		}
+		_, err := olaMap.ReverseGeocode(ping.Destination(0, 500))
+		assert.Nil(t, err)
+
+		assert.Len(t, mocking.urls, 2)
+		assert.Equal(t, CacheCounts{Hits: 9, Misses: 2}, cache.Stats())
+	})
+}
@@ -0,0 +1,258 @@
+package golamaptest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/golang-mitrah/golamap"
+)
+
+// Redacted replaces secrets in recorded interactions
+const Redacted = golamap.Redacted
+
+// ErrUnmatched is returned when a replayed request has no recorded
+// interaction
+var ErrUnmatched = errors.New("golamaptest: no recorded interaction")
+
+// Mode selects whether a Recorder records or replays
+type Mode int
+
+const (
+	ModeReplay Mode = iota // Answer from the cassette, never touching the network
+	ModeRecord             // Send requests and record them to the cassette
+)
+
+// Cassette is a recorded sequence of HTTP interactions
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is one recorded request and its response
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a request as stored in a cassette, secrets redacted
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is a response as stored in a cassette. Binary bodies,
+// such as static map images, are base64 encoded.
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	Encoding   string      `json:"encoding,omitempty"` // "base64" for binary bodies
+}
+
+// Recorder is a transport that records a client's HTTP interactions to a
+// cassette file, or replays them from it:
+//
+//	recorder, err := golamaptest.NewRecorder("testdata/geocode.json", golamaptest.ModeReplay)
+//	olaMap := golamap.NewClient(golamap.WithMiddleware(recorder.Middleware()), golamap.WithAPIKey(key))
+//	defer recorder.Save()
+//
+// Bearer tokens, client secrets, api_key parameters and issued access
+// tokens are redacted before they are written. Replayed requests match on
+// method, path and query, ignoring api_key and the order of parameters;
+// each interaction answers once, in recorded order.
+type Recorder struct {
+	path string
+	mode Mode
+	next http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// NewRecorder returns a Recorder for the cassette at path. In ModeReplay
+// the cassette must exist; in ModeRecord it is written by Save.
+func NewRecorder(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{path: path, mode: mode, next: http.DefaultTransport}
+	if mode == ModeRecord {
+		return r, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &r.cassette); err != nil {
+		return nil, fmt.Errorf("golamaptest: invalid cassette %s: %w", path, err)
+	}
+	r.used = make([]bool, len(r.cassette.Interactions))
+
+	return r, nil
+}
+
+// Middleware installs the Recorder in a client's transport stack with
+// golamap.WithMiddleware. Recorded requests are sent through next.
+func (r *Recorder) Middleware() golamap.Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		r.mu.Lock()
+		r.next = next
+		r.mu.Unlock()
+		return r
+	}
+}
+
+// Cassette returns the interactions recorded or loaded so far
+func (r *Recorder) Cassette() Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return Cassette{Interactions: append([]Interaction(nil), r.cassette.Interactions...)}
+}
+
+// Save writes the recorded cassette to its file. It does nothing when
+// replaying.
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, data, 0o644)
+}
+
+// RoundTrip records or replays req
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.mode == ModeRecord {
+		return r.record(req)
+	}
+	return r.replay(req)
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	r.mu.Lock()
+	next := r.next
+	r.mu.Unlock()
+
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	interaction := Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    golamap.RedactURL(req.URL),
+			Header: golamap.RedactHeader(req.Header),
+			Body:   golamap.RedactForm(string(reqBody)),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header.Clone(),
+		},
+	}
+	if utf8.Valid(respBody) {
+		interaction.Response.Body = redactTokens(string(respBody))
+	} else {
+		interaction.Response.Body = base64.StdEncoding.EncodeToString(respBody)
+		interaction.Response.Encoding = "base64"
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	key := matchKey(req.Method, req.URL)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] {
+			continue
+		}
+		recorded, err := url.Parse(interaction.Request.URL)
+		if err != nil || matchKey(interaction.Request.Method, recorded) != key {
+			continue
+		}
+		r.used[i] = true
+
+		body := []byte(interaction.Response.Body)
+		if interaction.Response.Encoding == "base64" {
+			if body, err = base64.StdEncoding.DecodeString(interaction.Response.Body); err != nil {
+				return nil, fmt.Errorf("golamaptest: invalid body in cassette %s: %w", r.path, err)
+			}
+		}
+
+		header := interaction.Response.Header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w for %s in %s", ErrUnmatched, key, r.path)
+}
+
+// matchKey identifies a request for replay: method, path and the query
+// without api_key, with parameters sorted
+func matchKey(method string, u *url.URL) string {
+	query := u.Query()
+	query.Del("api_key")
+	key := method + " " + u.Path
+	if len(query) > 0 {
+		key += "?" + query.Encode()
+	}
+	return key
+}
+
+var tokenField = regexp.MustCompile(`("(?:access_token|refresh_token|id_token)"\s*:\s*)"[^"]*"`)
+
+// redactTokens redacts the tokens issued in a token response body
+func redactTokens(body string) string {
+	return tokenField.ReplaceAllString(body, `${1}"`+Redacted+`"`)
+}
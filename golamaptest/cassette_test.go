@@ -0,0 +1,97 @@
+package golamaptest
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang-mitrah/golamap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecorder(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	cassette := filepath.Join(t.TempDir(), "cassette.json")
+
+	t.Run("record", func(t *testing.T) {
+		recorder, err := NewRecorder(cassette, ModeRecord)
+		assert.Nil(t, err)
+		olaMap := server.NewClient(
+			golamap.WithMiddleware(recorder.Middleware()),
+			golamap.WithClientCredentials("mock-client-id", "mock-client-secret"),
+		)
+
+		_, err = olaMap.GeoCode("mumbai", golamap.Bounds{}, "")
+		assert.Nil(t, err)
+		_, err = olaMap.ReverseGeocode(origin)
+		assert.Nil(t, err)
+		_, err = olaMap.StaticMap(context.Background(), golamap.NewStaticMap("default-light-standard").Center(origin, 15).Size(8, 8))
+		assert.Nil(t, err)
+		assert.Nil(t, recorder.Save())
+
+		interactions := recorder.Cassette().Interactions
+		assert.Len(t, interactions, 4)
+		assert.Equal(t, "base64", interactions[3].Response.Encoding)
+
+		data, err := os.ReadFile(cassette)
+		assert.Nil(t, err)
+		assert.NotContains(t, string(data), "mock-client-secret")
+		assert.NotContains(t, string(data), AccessToken)
+		assert.Equal(t, `{"access_token":"REDACTED","token_type":"Bearer","expires_in":3600}`, interactions[0].Response.Body)
+		assert.Equal(t, "client_id=mock-client-id&client_secret=REDACTED&grant_type=client_credentials&scope=openid", interactions[0].Request.Body)
+		assert.Equal(t, "REDACTED", interactions[1].Request.Header.Get("Authorization"))
+	})
+
+	t.Run("replay", func(t *testing.T) {
+		server.Reset()
+		recorder, err := NewRecorder(cassette, ModeReplay)
+		assert.Nil(t, err)
+		olaMap := golamap.NewClient(
+			golamap.WithMiddleware(recorder.Middleware()),
+			golamap.WithBaseURL(golamap.APIAuth, server.URL),
+			golamap.WithBaseURL(golamap.APIPlaces, server.URL),
+			golamap.WithBaseURL(golamap.APITiles, server.URL),
+			golamap.WithClientCredentials("other-client-id", "other-client-secret"),
+		)
+
+		geocode, err := olaMap.GeoCode("mumbai", golamap.Bounds{}, "")
+		assert.Nil(t, err)
+		assert.NotEmpty(t, geocode.GeocodingResults)
+		reverse, err := olaMap.ReverseGeocode(origin)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, reverse.Results)
+		image, err := olaMap.StaticMap(context.Background(), golamap.NewStaticMap("default-light-standard").Center(origin, 15).Size(8, 8))
+		assert.Nil(t, err)
+		assert.Equal(t, 8, image.Width)
+		assert.NotEmpty(t, image.Data)
+
+		assert.Empty(t, server.Requests(), "replay never touches the network")
+	})
+
+	t.Run("unmatched", func(t *testing.T) {
+		recorder, err := NewRecorder(cassette, ModeReplay)
+		assert.Nil(t, err)
+		olaMap := golamap.NewClient(golamap.WithMiddleware(recorder.Middleware()), golamap.WithAPIKey("mock-api-key"))
+
+		_, err = olaMap.GeoCode("delhi", golamap.Bounds{}, "")
+		assert.ErrorIs(t, err, ErrUnmatched)
+		assert.True(t, strings.Contains(err.Error(), "GET /places/v1/geocode?address=delhi"), err.Error())
+	})
+
+	t.Run("missing cassette", func(t *testing.T) {
+		_, err := NewRecorder(filepath.Join(t.TempDir(), "missing.json"), ModeReplay)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestMatchKey(t *testing.T) {
+	a, _ := url.Parse("https://api.olamaps.io/places/v1/geocode?language=&address=mumbai&bounds=&api_key=one")
+	b, _ := url.Parse("http://127.0.0.1/places/v1/geocode?address=mumbai&bounds=&language=&api_key=two")
+	assert.Equal(t, matchKey("GET", a), matchKey("GET", b))
+	assert.NotEqual(t, matchKey("GET", a), matchKey("POST", b))
+	assert.Equal(t, "https://api.olamaps.io/places/v1/geocode?address=mumbai&api_key=REDACTED&bounds=&language=", golamap.RedactURL(a))
+}
@@ -0,0 +1,228 @@
+package golamaptest
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-mitrah/golamap"
+)
+
+// Fault is a failure injected into the server's answer. Fields combine,
+// e.g. a Delay followed by a Status. net/http resends an idempotent request
+// once when a reused connection closes before answering, so a Reset limited
+// to a single call may go unnoticed by the client.
+type Fault struct {
+	Status      int           // Answer with this error status instead of the fixture
+	RetryAfter  time.Duration // Retry-After header sent with Status, rounded up to whole seconds
+	Delay       time.Duration // Wait before answering, e.g. to trip a client timeout
+	Truncate    bool          // Cut the body in half, leaving invalid JSON
+	SlowBody    time.Duration // Pause between each chunk of the body
+	ExpireToken bool          // Revoke every issued access token and answer 401
+	Reset       bool          // Close the connection without answering
+}
+
+// Rule injects Fault into calls From through To, counted from 1, to
+// Endpoint. To 0 means every call from From onwards, and an empty Endpoint
+// matches every endpoint.
+type Rule struct {
+	Endpoint golamap.Endpoint
+	From     int
+	To       int
+	Fault    Fault
+}
+
+// Scenario is a list of rules. The first rule matching a call applies.
+type Scenario []Rule
+
+// Inject adds rules to the server's scenario. Reset removes them.
+func (s *Server) Inject(rules ...Rule) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scenario = append(s.scenario, rules...)
+}
+
+// Script parses script with ParseScenario and injects it. It panics if
+// the script is invalid, which suits scripts written inline in tests.
+func (s *Server) Script(script string) {
+	scenario, err := ParseScenario(script)
+	if err != nil {
+		panic(err)
+	}
+	s.Inject(scenario...)
+}
+
+// ParseScenario parses a fault script, one rule per line:
+//
+//	distanceMatrix#1-2 503                 // fail the first 2 calls
+//	geocode#1 429 retry-after=2s           // rate limit the first call
+//	directions#3- delay=5s                 // stall from the third call on
+//	reverse-geocode truncate               // truncate every answer
+//	style#2 expire-token                   // revoke the token mid-session
+//	details reset                          // drop every connection
+//
+// A rule names an Endpoint, or * for any, optionally followed by #N, #N-M,
+// #N- or #* to select calls. Faults are an error status (or status=N),
+// retry-after=D, delay=D, slow-body=D, truncate, expire-token and reset,
+// with durations in time.ParseDuration form. Text after // is ignored.
+func ParseScenario(script string) (Scenario, error) {
+	var scenario Scenario
+	for n, line := range strings.Split(script, "\n") {
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		rule, err := parseRule(fields)
+		if err != nil {
+			return nil, fmt.Errorf("golamaptest: scenario line %d: %w", n+1, err)
+		}
+		scenario = append(scenario, rule)
+	}
+	return scenario, nil
+}
+
+func parseRule(fields []string) (Rule, error) {
+	var rule Rule
+	target, calls, _ := strings.Cut(fields[0], "#")
+	if target != "*" {
+		rule.Endpoint = golamap.Endpoint(target)
+		if _, ok := endpointNames()[rule.Endpoint]; !ok {
+			return Rule{}, fmt.Errorf("unknown endpoint %q", target)
+		}
+	}
+
+	if calls != "" && calls != "*" {
+		from, to, isRange := strings.Cut(calls, "-")
+		var err error
+		if rule.From, err = strconv.Atoi(from); err != nil || rule.From < 1 {
+			return Rule{}, fmt.Errorf("invalid calls %q", calls)
+		}
+		rule.To = rule.From
+		if isRange {
+			rule.To = 0
+			if to != "" {
+				if rule.To, err = strconv.Atoi(to); err != nil || rule.To < rule.From {
+					return Rule{}, fmt.Errorf("invalid calls %q", calls)
+				}
+			}
+		}
+	}
+
+	if len(fields) == 1 {
+		return Rule{}, fmt.Errorf("no fault for %q", fields[0])
+	}
+	for _, field := range fields[1:] {
+		name, value, _ := strings.Cut(field, "=")
+		var err error
+		switch name {
+		case "status":
+			rule.Fault.Status, err = strconv.Atoi(value)
+		case "retry-after":
+			rule.Fault.RetryAfter, err = time.ParseDuration(value)
+		case "delay":
+			rule.Fault.Delay, err = time.ParseDuration(value)
+		case "slow-body":
+			rule.Fault.SlowBody, err = time.ParseDuration(value)
+		case "truncate":
+			rule.Fault.Truncate = true
+		case "expire-token":
+			rule.Fault.ExpireToken = true
+		case "reset":
+			rule.Fault.Reset = true
+		default:
+			if rule.Fault.Status, err = strconv.Atoi(field); err != nil {
+				return Rule{}, fmt.Errorf("unknown fault %q", field)
+			}
+		}
+		if err != nil {
+			return Rule{}, fmt.Errorf("invalid fault %q: %w", field, err)
+		}
+	}
+
+	return rule, nil
+}
+
+func endpointNames() map[golamap.Endpoint]struct{} {
+	names := map[golamap.Endpoint]struct{}{}
+	for _, e := range golamap.Endpoints() {
+		names[e] = struct{}{}
+	}
+	return names
+}
+
+// faultFor returns the fault for call number call to e. s.mu must be held.
+func (s *Server) faultFor(e golamap.Endpoint, call int) (Fault, bool) {
+	for _, rule := range s.scenario {
+		if rule.Endpoint != "" && rule.Endpoint != e {
+			continue
+		}
+		if call < rule.From || (rule.To > 0 && call > rule.To) {
+			continue
+		}
+		return rule.Fault, true
+	}
+	return Fault{}, false
+}
+
+// token returns the access token currently issued. s.mu must be held.
+func (s *Server) token() string {
+	if s.generation == 0 {
+		return AccessToken
+	}
+	return fmt.Sprintf("%s-%d", AccessToken, s.generation)
+}
+
+// injectFault answers with fault, reporting false if the fault only alters
+// the normal answer
+func (s *Server) injectFault(w http.ResponseWriter, fault Fault) bool {
+	switch {
+	case fault.Reset:
+		if hijacker, ok := w.(http.Hijacker); ok {
+			if conn, _, err := hijacker.Hijack(); err == nil {
+				conn.Close()
+				return true
+			}
+		}
+		panic(http.ErrAbortHandler)
+	case fault.ExpireToken:
+		s.mu.Lock()
+		s.generation++
+		s.mu.Unlock()
+		writeJSON(w, http.StatusUnauthorized, errorBody(http.StatusUnauthorized, "access token expired"))
+		return true
+	case fault.Status != 0:
+		if fault.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(fault.RetryAfter.Seconds()))))
+		}
+		writeJSON(w, fault.Status, errorBody(fault.Status, http.StatusText(fault.Status)))
+		return true
+	}
+	return false
+}
+
+// writeSlowly writes body in small chunks, pausing between them
+func writeSlowly(ctx context.Context, w http.ResponseWriter, status int, body []byte, pause time.Duration) {
+	const chunk = 64
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(status)
+	flusher, _ := w.(http.Flusher)
+	for len(body) > 0 {
+		n := min(chunk, len(body))
+		w.Write(body[:n])
+		body = body[n:]
+		if flusher != nil {
+			flusher.Flush()
+		}
+		if len(body) > 0 && !sleep(ctx, pause) {
+			return
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package golamaptest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang-mitrah/golamap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseScenario(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		scenario, err := ParseScenario(`
+			// resilience scenario
+			distanceMatrix#1-2 503
+			geocode#1 status=429 retry-after=2s
+			directions#3- delay=5s   // stall from the third call on
+			reverse-geocode truncate slow-body=10ms
+			*#4 reset
+			style#2 expire-token
+		`)
+		assert.Nil(t, err)
+		assert.Equal(t, Scenario{
+			{Endpoint: golamap.EndpointDistanceMatrix, From: 1, To: 2, Fault: Fault{Status: 503}},
+			{Endpoint: golamap.EndpointGeoCode, From: 1, To: 1, Fault: Fault{Status: 429, RetryAfter: 2 * time.Second}},
+			{Endpoint: golamap.EndpointDirections, From: 3, Fault: Fault{Delay: 5 * time.Second}},
+			{Endpoint: golamap.EndpointReverseGeocode, Fault: Fault{Truncate: true, SlowBody: 10 * time.Millisecond}},
+			{From: 4, To: 4, Fault: Fault{Reset: true}},
+			{Endpoint: golamap.EndpointStyleDetails, From: 2, To: 2, Fault: Fault{ExpireToken: true}},
+		}, scenario)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		for script, expected := range map[string]string{
+			"distance 503":      `golamaptest: scenario line 1: unknown endpoint "distance"`,
+			"geocode#0 503":     `golamaptest: scenario line 1: invalid calls "0"`,
+			"geocode#3-1 503":   `golamaptest: scenario line 1: invalid calls "3-1"`,
+			"geocode":           `golamaptest: scenario line 1: no fault for "geocode"`,
+			"\ngeocode explode": `golamaptest: scenario line 2: unknown fault "explode"`,
+			"geocode delay=2":   `golamaptest: scenario line 1: invalid fault "delay=2": time: missing unit in duration "2"`,
+		} {
+			_, err := ParseScenario(script)
+			assert.EqualError(t, err, expected, script)
+		}
+	})
+}
+
+func TestFaults(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	retry := golamap.DefaultRetryPolicy()
+	retry.InitialBackoff = time.Millisecond
+	retry.MaxBackoff = 5 * time.Millisecond
+
+	t.Run("5xx burst is retried", func(t *testing.T) {
+		server.Reset()
+		server.Script("distanceMatrix#1-2 503")
+		olaMap := server.NewClient(golamap.WithAPIKey("mock-api-key"), golamap.WithRetryPolicy(retry))
+
+		_, err := olaMap.GetDistanceMatrix(golamap.Path{origin}, golamap.Path{destination})
+		assert.Nil(t, err)
+		assert.Equal(t, 3, server.Count(golamap.EndpointDistanceMatrix))
+	})
+	t.Run("5xx burst outlasting retries", func(t *testing.T) {
+		server.Reset()
+		server.Script("distanceMatrix 502")
+		olaMap := server.NewClient(golamap.WithAPIKey("mock-api-key"), golamap.WithRetryPolicy(retry))
+
+		_, err := olaMap.GetDistanceMatrix(golamap.Path{origin}, golamap.Path{destination})
+		var apiErr *golamap.APIError
+		assert.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, http.StatusBadGateway, apiErr.StatusCode)
+		assert.Equal(t, retry.MaxAttempts, server.Count(golamap.EndpointDistanceMatrix))
+	})
+	t.Run("429 with Retry-After", func(t *testing.T) {
+		server.Reset()
+		server.Inject(Rule{Endpoint: golamap.EndpointGeoCode, From: 1, To: 1, Fault: Fault{Status: http.StatusTooManyRequests, RetryAfter: 1500 * time.Millisecond}})
+		olaMap := server.NewClient(golamap.WithAPIKey("mock-api-key"))
+
+		_, err := olaMap.GeoCode("mumbai", golamap.Bounds{}, "")
+		assert.ErrorIs(t, err, golamap.ErrRateLimited)
+
+		resp, err := http.Get(server.URL + "/places/v1/geocode?api_key=mock-api-key")
+		assert.Nil(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "only the first call is limited")
+
+		server.Reset()
+		server.Script("geocode 429 retry-after=2s")
+		resp, err = http.Get(server.URL + "/places/v1/geocode?api_key=mock-api-key")
+		assert.Nil(t, err)
+		resp.Body.Close()
+		assert.Equal(t, "2", resp.Header.Get("Retry-After"))
+	})
+	t.Run("timeout", func(t *testing.T) {
+		server.Reset()
+		server.Script("directions delay=1s")
+		olaMap := server.NewClient(golamap.WithAPIKey("mock-api-key"), golamap.WithTimeout(20*time.Millisecond))
+
+		_, err := olaMap.GetDirections(origin, destination)
+		assert.Error(t, err)
+		var timeout interface{ Timeout() bool }
+		assert.True(t, errors.As(err, &timeout) && timeout.Timeout(), err.Error())
+	})
+	t.Run("truncated JSON", func(t *testing.T) {
+		server.Reset()
+		server.Script("reverse-geocode truncate")
+		olaMap := server.NewClient(golamap.WithAPIKey("mock-api-key"))
+
+		_, err := olaMap.ReverseGeocode(origin)
+		var syntaxErr *json.SyntaxError
+		assert.True(t, errors.As(err, &syntaxErr), err.Error())
+	})
+	t.Run("slow body", func(t *testing.T) {
+		server.Reset()
+		server.Script("geocode slow-body=5ms")
+		olaMap := server.NewClient(golamap.WithAPIKey("mock-api-key"))
+		start := time.Now()
+		_, err := olaMap.GeoCode("mumbai", golamap.Bounds{}, "")
+		assert.Nil(t, err)
+		assert.Greater(t, time.Since(start), 50*time.Millisecond)
+
+		olaMap = server.NewClient(golamap.WithAPIKey("mock-api-key"), golamap.WithTimeout(30*time.Millisecond))
+		_, err = olaMap.GeoCode("mumbai", golamap.Bounds{}, "")
+		assert.Error(t, err, "the body outlasts the client timeout")
+	})
+	t.Run("token expiry mid-session", func(t *testing.T) {
+		server.Reset()
+		server.Script("geocode#2 expire-token")
+		olaMap := server.NewClient(golamap.WithClientCredentials("mock-client-id", "mock-client-secret"))
+
+		for i := 0; i < 3; i++ {
+			_, err := olaMap.GeoCode("mumbai", golamap.Bounds{}, "")
+			assert.Nil(t, err)
+		}
+		assert.Equal(t, 2, server.Count(golamap.EndpointToken), "the client fetched a new token after the 401")
+		assert.Equal(t, 4, server.Count(golamap.EndpointGeoCode))
+
+		requests := server.Requests()
+		assert.Equal(t, "Bearer "+AccessToken+"-1", requests[len(requests)-1].Header.Get("Authorization"))
+	})
+	t.Run("connection reset", func(t *testing.T) {
+		server.Reset()
+		server.Script("details reset")
+		olaMap := server.NewClient(golamap.WithAPIKey("mock-api-key"))
+
+		_, err := olaMap.GetPlaceDetail("mock-place-id")
+		assert.Error(t, err)
+
+		server.Reset()
+		_, err = olaMap.GetPlaceDetail("mock-place-id")
+		assert.Nil(t, err)
+	})
+}
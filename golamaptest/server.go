@@ -0,0 +1,326 @@
+// Package golamaptest provides an in-process fake of the Ola Maps API for
+// tests. It serves the token endpoint and every endpoint the golamap client
+// calls, answering with the fixtures in golamap's mock_response.go unless a
+// route is overridden:
+//
+//	server := golamaptest.NewServer()
+//	defer server.Close()
+//
+//	olaMap := server.NewClient(golamap.WithClientCredentials("id", "secret"))
+//	server.Handle(golamap.EndpointGeoCode, golamaptest.Route{Status: http.StatusServiceUnavailable})
+package golamaptest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-mitrah/golamap"
+)
+
+// AccessToken is the access token issued by the fake token endpoint until
+// an ExpireToken fault revokes it
+const AccessToken = "golamaptest-access-token"
+
+// Route overrides how the server answers requests for one endpoint. Zero
+// fields keep their defaults. Credentials are still checked unless the route
+// sets a Status or Body.
+type Route struct {
+	Status  int           // Response status; defaults to 200
+	Body    string        // Response body; defaults to the endpoint's fixture, or an error payload for non-2xx statuses
+	Header  http.Header   // Extra response headers
+	Latency time.Duration // Delay before answering, cut short if the request is canceled
+}
+
+// Request is a request received by the server
+type Request struct {
+	Endpoint golamap.Endpoint
+	Method   string
+	URL      *url.URL
+	Header   http.Header
+	Body     []byte
+}
+
+// Server is a fake Ola Maps API. Every API family is served from URL.
+type Server struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	routes     map[golamap.Endpoint]Route
+	requests   []Request
+	scenario   Scenario
+	generation int // Bumped by ExpireToken faults to revoke issued tokens
+}
+
+// NewServer starts a Server. Close it when done.
+func NewServer() *Server {
+	s := &Server{routes: map[golamap.Endpoint]Route{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// NewClient returns a client pointed at the server for every API family,
+// followed by opts. Add WithAPIKey or WithClientCredentials to authenticate;
+// the server accepts any key or client credentials.
+func (s *Server) NewClient(opts ...golamap.Option) *golamap.OLAMap {
+	base := []golamap.Option{golamap.WithHTTPClient(s.Client())}
+	for _, family := range []golamap.APIFamily{golamap.APIAuth, golamap.APIRouting, golamap.APIPlaces, golamap.APITiles} {
+		base = append(base, golamap.WithBaseURL(family, s.URL))
+	}
+	return golamap.NewClient(append(base, opts...)...)
+}
+
+// Handle overrides the answer to requests for e
+func (s *Server) Handle(e golamap.Endpoint, route Route) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.routes[e] = route
+}
+
+// Reset removes every override and fault and forgets the received requests
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.routes = map[golamap.Endpoint]Route{}
+	s.requests = nil
+	s.scenario = nil
+}
+
+// Requests returns the requests received so far, oldest first
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// Count returns how many requests for e the server received
+func (s *Server) Count(e golamap.Endpoint) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	count := 0
+	for _, r := range s.requests {
+		if r.Endpoint == e {
+			count++
+		}
+	}
+	return count
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	e, ok := Match(r.URL.Path)
+	if !ok {
+		writeJSON(w, http.StatusNotFound, errorBody(http.StatusNotFound, "no such endpoint "+r.URL.Path))
+		return
+	}
+
+	var body bytes.Buffer
+	body.ReadFrom(r.Body)
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Endpoint: e, Method: r.Method, URL: r.URL, Header: r.Header.Clone(), Body: body.Bytes()})
+	call := 0
+	for _, req := range s.requests {
+		if req.Endpoint == e {
+			call++
+		}
+	}
+	route := s.routes[e]
+	fault, faulted := s.faultFor(e, call)
+	token := s.token()
+	s.mu.Unlock()
+
+	if route.Latency > 0 {
+		if !sleep(r.Context(), route.Latency) {
+			return
+		}
+	}
+	if faulted {
+		if fault.Delay > 0 && !sleep(r.Context(), fault.Delay) {
+			return
+		}
+		if s.injectFault(w, fault) {
+			return
+		}
+	}
+	for name, values := range route.Header {
+		w.Header()[name] = values
+	}
+
+	if route.Status == 0 && route.Body == "" {
+		if status, msg, ok := checkAuth(e, r, body.Bytes(), token); !ok {
+			writeJSON(w, status, errorBody(status, msg))
+			return
+		}
+	}
+
+	status := route.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	contentType, payload := "application/json", []byte(route.Body)
+	switch {
+	case route.Body != "":
+	case status < 200 || status > 299:
+		payload = []byte(errorBody(status, http.StatusText(status)))
+	case isStatic(e):
+		contentType, payload = "image/png", staticImage(r.URL.Path)
+	case e == golamap.EndpointToken:
+		payload = []byte(tokenBody(token))
+	default:
+		payload = []byte(Fixture(e))
+	}
+
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", contentType)
+	}
+	if faulted && fault.Truncate {
+		payload = payload[:len(payload)/2]
+	}
+	if faulted && fault.SlowBody > 0 {
+		writeSlowly(r.Context(), w, status, payload, fault.SlowBody)
+		return
+	}
+	w.WriteHeader(status)
+	w.Write(payload)
+}
+
+// checkAuth rejects requests the real API would answer with 401
+func checkAuth(e golamap.Endpoint, r *http.Request, body []byte, token string) (int, string, bool) {
+	if e == golamap.EndpointToken {
+		form, err := url.ParseQuery(string(body))
+		if err != nil || form.Get("grant_type") != "client_credentials" || form.Get("client_id") == "" || form.Get("client_secret") == "" {
+			return http.StatusUnauthorized, "invalid client credentials", false
+		}
+		return 0, "", true
+	}
+
+	if r.URL.Query().Get("api_key") != "" || r.Header.Get("Authorization") == "Bearer "+token {
+		return 0, "", true
+	}
+	return http.StatusUnauthorized, "missing or invalid credentials", false
+}
+
+func tokenBody(token string) string {
+	return fmt.Sprintf(`{"access_token":%q,"token_type":"Bearer","expires_in":3600}`, token)
+}
+
+// Fixture returns the JSON body served for e by default
+func Fixture(e golamap.Endpoint) string {
+	switch e {
+	case golamap.EndpointToken:
+		return tokenBody(AccessToken)
+	case golamap.EndpointDirections:
+		return golamap.DirectionResponse
+	case golamap.EndpointPlaceAutoComplete:
+		return golamap.AutoCompleteResponse
+	case golamap.EndpointGeoCode:
+		return golamap.GeoCodeResponse
+	case golamap.EndpointReverseGeocode:
+		return golamap.ReverseGeocodeResponse
+	case golamap.EndpointDistanceMatrix:
+		return golamap.DistanceMatrixResponse
+	case golamap.EndpointArrayOfData:
+		return golamap.ArrayOfDataResponse
+	case golamap.EndpointStyleDetails:
+		return golamap.StyleDetailResponse
+	case golamap.EndpointMapStyle:
+		return golamap.MapStyleResponse
+	case golamap.EndpointPlaceDetail:
+		return golamap.PlaceDetailResponse
+	case golamap.EndpointNearBySearch:
+		return golamap.NearBySearchResponses
+	case golamap.EndpointTextSearch:
+		return golamap.TextSearchResponse
+	case golamap.EndpointSnapToRoad:
+		return golamap.SnapToRoadResponse
+	case golamap.EndpointNearestRoads:
+		return golamap.NearestRoadResponse
+	}
+	return ""
+}
+
+var (
+	patternsOnce sync.Once
+	patterns     []endpointPattern
+)
+
+type endpointPattern struct {
+	endpoint golamap.Endpoint
+	path     *regexp.Regexp
+}
+
+var verb = regexp.MustCompile(`%[a-z]`)
+
+// Match returns the endpoint whose path template matches path
+func Match(path string) (golamap.Endpoint, bool) {
+	patternsOnce.Do(func() {
+		for _, e := range golamap.Endpoints() {
+			template, _, _ := strings.Cut(e.Path(), "?")
+			parts := verb.Split(template, -1)
+			for i := range parts {
+				parts[i] = regexp.QuoteMeta(parts[i])
+			}
+			patterns = append(patterns, endpointPattern{e, regexp.MustCompile("^" + strings.Join(parts, "[^/,]+") + "$")})
+		}
+	})
+
+	for _, p := range patterns {
+		if p.path.MatchString(path) {
+			return p.endpoint, true
+		}
+	}
+	return "", false
+}
+
+func isStatic(e golamap.Endpoint) bool {
+	return e == golamap.EndpointStaticMapImageCenter || e == golamap.EndpointStaticMapImageBounded || e == golamap.EndpointStaticMapImage
+}
+
+var sizePattern = regexp.MustCompile(`/(\d+)x(\d+)\.[a-z]+$`)
+
+// staticImage renders a blank PNG of the size requested in path
+func staticImage(path string) []byte {
+	width, height := 1, 1
+	if m := sizePattern.FindStringSubmatch(path); m != nil {
+		fmt.Sscan(m[1], &width)
+		fmt.Sscan(m[2], &height)
+	}
+
+	var buf bytes.Buffer
+	png.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height)))
+	return buf.Bytes()
+}
+
+func errorBody(status int, msg string) string {
+	return fmt.Sprintf(`{"status":%q,"error_message":%q}`, http.StatusText(status), msg)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body string) {
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "application/json")
+	}
+	w.WriteHeader(status)
+	w.Write([]byte(body))
+}
+
+// sleep waits for d, reporting false if ctx is done first
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
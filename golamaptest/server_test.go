@@ -0,0 +1,165 @@
+package golamaptest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang-mitrah/golamap"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	origin      = golamap.LatLng{Lat: 12.993103, Lng: 77.543326}
+	destination = golamap.LatLng{Lat: 12.972006, Lng: 77.580085}
+)
+
+func TestServer(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	t.Run("every endpoint decodes its fixture", func(t *testing.T) {
+		olaMap := server.NewClient(golamap.WithClientCredentials("mock-client-id", "mock-client-secret"))
+		ctx := context.Background()
+
+		directions, err := olaMap.GetDirections(origin, destination)
+		assert.Nil(t, err)
+		assert.Equal(t, "0,1,0 | 1,3,15 | 3,4,10", directions.Routes[0].TravelAdvisory)
+
+		autoComplete, err := olaMap.PlaceAutoComplete("kempe")
+		assert.Nil(t, err)
+		assert.NotEmpty(t, autoComplete.Predictions)
+
+		geocode, err := olaMap.GeoCode("mumbai", golamap.Bounds{}, "")
+		assert.Nil(t, err)
+		assert.NotEmpty(t, geocode.GeocodingResults)
+
+		reverse, err := olaMap.ReverseGeocode(origin)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"country"}, reverse.Results[0].AddressComponents[0].Types)
+
+		matrix, err := olaMap.GetDistanceMatrix(golamap.Path{origin}, golamap.Path{destination})
+		assert.Nil(t, err)
+		assert.Equal(t, 3224, matrix.Rows[0].Elements[0].Distance)
+
+		data, err := olaMap.ArrayOfData("planet")
+		assert.Nil(t, err)
+		assert.Equal(t, "planet", data.ID)
+
+		_, err = olaMap.GetStyleDetails("default-light-standard")
+		assert.Nil(t, err)
+
+		styles, err := olaMap.GetMapStyle()
+		assert.Nil(t, err)
+		assert.NotEmpty(t, styles)
+
+		_, err = olaMap.GetPlaceDetail("ola-platform:a79ed32419962a11a588ea92b83ca78e")
+		assert.Nil(t, err)
+
+		_, err = olaMap.GetNearBySearch(golamap.NearBySearch{Layers: "venue", Location: origin})
+		assert.Nil(t, err)
+
+		_, err = olaMap.GetTextSearch(golamap.TextSearch{Input: "cafes"})
+		assert.Nil(t, err)
+
+		_, err = olaMap.GetSnapToRoad(golamap.Path{origin, destination}, "false")
+		assert.Nil(t, err)
+
+		_, err = olaMap.GetNearestRoads(golamap.Path{origin}, "500")
+		assert.Nil(t, err)
+
+		image, err := olaMap.StaticMap(ctx, golamap.NewStaticMap("default-light-standard").Center(origin, 15).Size(64, 32))
+		assert.Nil(t, err)
+		assert.Equal(t, "image/png", image.ContentType)
+		assert.NotEmpty(t, image.Data)
+
+		_, err = olaMap.StaticMap(ctx, golamap.NewStaticMap("default-light-standard").BBox(golamap.BoundsOf([]golamap.Location{origin, destination})))
+		assert.Nil(t, err)
+
+		_, err = olaMap.StaticMap(ctx, golamap.NewStaticMap("default-light-standard").AddMarker(golamap.MapMarker{Position: origin}))
+		assert.Nil(t, err)
+
+		assert.Equal(t, 1, server.Count(golamap.EndpointToken))
+		for _, e := range golamap.Endpoints() {
+			assert.Equal(t, 1, server.Count(e), e)
+		}
+	})
+
+	t.Run("requires credentials", func(t *testing.T) {
+		server.Reset()
+		olaMap := server.NewClient(golamap.WithToken("Bearer not-issued-by-the-server"))
+		_, err := olaMap.GeoCode("mumbai", golamap.Bounds{}, "")
+		assert.ErrorIs(t, err, golamap.ErrUnauthorized)
+
+		olaMap = server.NewClient(golamap.WithAPIKey("mock-api-key"))
+		_, err = olaMap.GeoCode("mumbai", golamap.Bounds{}, "")
+		assert.Nil(t, err)
+		assert.Equal(t, "mock-api-key", server.Requests()[1].URL.Query().Get("api_key"))
+	})
+
+	t.Run("status override", func(t *testing.T) {
+		server.Reset()
+		server.Handle(golamap.EndpointGeoCode, Route{Status: http.StatusServiceUnavailable, Header: http.Header{"X-Correlation-Id": {"mock-correlation-id"}}})
+		olaMap := server.NewClient(golamap.WithAPIKey("mock-api-key"))
+
+		_, err := olaMap.GeoCode("mumbai", golamap.Bounds{}, "")
+		var apiErr *golamap.APIError
+		assert.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+		assert.Equal(t, "Service Unavailable", apiErr.ErrorMessage)
+		assert.Equal(t, "mock-correlation-id", apiErr.CorrelationID)
+
+		_, err = olaMap.PlaceAutoComplete("kempe")
+		assert.Nil(t, err, "other endpoints keep their fixtures")
+	})
+
+	t.Run("payload override", func(t *testing.T) {
+		server.Reset()
+		server.Handle(golamap.EndpointPlaceDetail, Route{Body: `{"status":"ok","result":{"name":"Custom place"}}`})
+		olaMap := server.NewClient(golamap.WithAPIKey("mock-api-key"))
+
+		detail, err := olaMap.GetPlaceDetail("mock-place-id")
+		assert.Nil(t, err)
+		assert.Equal(t, "Custom place", detail.Resultplacedetail.Name)
+	})
+
+	t.Run("header and latency overrides still check credentials", func(t *testing.T) {
+		server.Reset()
+		server.Handle(golamap.EndpointGeoCode, Route{Latency: time.Millisecond, Header: http.Header{"X-Mock": {"mock"}}})
+		olaMap := server.NewClient(golamap.WithToken("Bearer not-issued-by-the-server"))
+
+		_, err := olaMap.GeoCode("mumbai", golamap.Bounds{}, "")
+		assert.ErrorIs(t, err, golamap.ErrUnauthorized)
+	})
+
+	t.Run("latency", func(t *testing.T) {
+		server.Reset()
+		server.Handle(golamap.EndpointGeoCode, Route{Latency: time.Second})
+		olaMap := server.NewClient(golamap.WithAPIKey("mock-api-key"))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		_, err := olaMap.GeoCodeWithContext(ctx, "mumbai", golamap.Bounds{}, "")
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestMatch(t *testing.T) {
+	for path, expected := range map[string]golamap.Endpoint{
+		"/routing/v1/directions":                                        golamap.EndpointDirections,
+		"/tiles/vector/v1/styles.json":                                  golamap.EndpointMapStyle,
+		"/tiles/vector/v1/styles/default-light-standard/style.json":     golamap.EndpointStyleDetails,
+		"/tiles/v1/styles/default/static/77.61,12.93,15/512x512.png":    golamap.EndpointStaticMapImageCenter,
+		"/tiles/v1/styles/default/static/77.5,12.9,77.6,13/512x512.png": golamap.EndpointStaticMapImageBounded,
+		"/tiles/v1/styles/default/static/auto/512x512.png":              golamap.EndpointStaticMapImage,
+	} {
+		e, ok := Match(path)
+		assert.True(t, ok, path)
+		assert.Equal(t, expected, e, path)
+	}
+
+	_, ok := Match("/unknown")
+	assert.False(t, ok)
+}
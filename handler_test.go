@@ -1,33 +1,40 @@
 package golamap
 
 import (
+	"context"
 	"fmt"
+	"net/url"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 )
 
+var (
+	mockOrigin      = LatLng{Lat: 12.993103152916301, Lng: 77.54332622119354}
+	mockDestination = LatLng{Lat: 12.972006793201695, Lng: 77.5800850011884}
+)
+
 func TestGetDirections(t *testing.T) {
 	t.Run("Invalid origin & destination", func(t *testing.T) {
 		olaMap := &OLAMap{}
 		olaMap.HttpService = &MockStruct{}
-		_, err := olaMap.GetDirections("", "")
+		_, err := olaMap.GetDirections(LatLng{}, LatLng{})
 		expectedErr := fmt.Errorf("Missing required query parameters: 'origin' and/or 'destination'")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 
 	})
 	t.Run("Invalid OAuth token", func(t *testing.T) {
 		olaMap := &OLAMap{}
-		_, err := olaMap.GetDirections("mock-origin", "mock-destination")
+		_, err := olaMap.GetDirections(mockOrigin, mockDestination)
 		expectedErr := fmt.Errorf("Invalid OAuth token")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 	})
 	t.Run("success", func(t *testing.T) {
 		olaMap := &OLAMap{}
 		olaMap.Token = "mockToken"
 		mocking := &MockStruct{}
 		olaMap.HttpService = mocking
-		_, err := olaMap.GetDirections("mock-origin", "mock-destination")
+		_, err := olaMap.GetDirections(mockOrigin, mockDestination)
 		assert.Nil(t, nil, err)
 		if mocking.StatusCode != 200 {
 			t.Error("expected : ", 200, "got : ", mocking.StatusCode)
@@ -43,14 +50,14 @@ func TestPlaceAutoComplete(t *testing.T) {
 		olaMap.HttpService = &MockStruct{}
 		_, err := olaMap.PlaceAutoComplete("")
 		expectedErr := fmt.Errorf("Missing required query parameters: 'input'")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 
 	})
 	t.Run("Invalid OAuth token", func(t *testing.T) {
 		olaMap := &OLAMap{}
 		_, err := olaMap.PlaceAutoComplete("mock-input")
 		expectedErr := fmt.Errorf("Invalid OAuth token")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 
 	})
 	t.Run("success", func(t *testing.T) {
@@ -71,16 +78,16 @@ func TestGecode(t *testing.T) {
 	t.Run("Invalid address", func(t *testing.T) {
 		olaMap := &OLAMap{}
 		olaMap.HttpService = &MockStruct{}
-		_, err := olaMap.GeoCode("", "", "")
+		_, err := olaMap.GeoCode("", Bounds{}, "")
 		expectedErr := fmt.Errorf("Missing required query parameters: 'address'")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 
 	})
 	t.Run("Invalid OAuth token", func(t *testing.T) {
 		olaMap := &OLAMap{}
-		_, err := olaMap.GeoCode("mock-address", "mock-bounds", "mock-language")
+		_, err := olaMap.GeoCode("mock-address", Bounds{}, "mock-language")
 		expectedErr := fmt.Errorf("Invalid OAuth token")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 
 	})
 	t.Run("success", func(t *testing.T) {
@@ -88,7 +95,7 @@ func TestGecode(t *testing.T) {
 		olaMap.Token = "mockToken"
 		mocking := &MockStruct{}
 		olaMap.HttpService = mocking
-		_, err := olaMap.GeoCode("mock-address", "mock-bounds", "mock-language")
+		_, err := olaMap.GeoCode("mock-address", Bounds{}, "mock-language")
 		assert.Nil(t, nil, err)
 		if mocking.StatusCode != 200 {
 			t.Error("expected : ", 200, "got : ", mocking.StatusCode)
@@ -96,22 +103,36 @@ func TestGecode(t *testing.T) {
 		assert.Equal(t, GeoCodeResponse, mocking.MockBody)
 
 	})
+	t.Run("bounds", func(t *testing.T) {
+		mocking := &fixtureService{body: GeoCodeResponse}
+		olaMap := &OLAMap{Token: "mockToken", HttpService: mocking}
+		bounds := Bounds{Southwest: LatLng{Lat: 12.8, Lng: 77.4}, Northeast: LatLng{Lat: 13.1, Lng: 77.8}}
+		_, err := olaMap.GeoCode("mock-address", bounds, "")
+		assert.Nil(t, err)
+		assert.Equal(t, "https://api.olamaps.io/places/v1/geocode?address=mock-address&bounds=12.8%2C77.4%7C13.1%2C77.8&language=", mocking.urls[0])
+	})
+	t.Run("invalid bounds", func(t *testing.T) {
+		olaMap := &OLAMap{Token: "mockToken", HttpService: &MockStruct{}}
+		_, err := olaMap.GeoCode("mock-address", Bounds{Southwest: LatLng{Lat: 100, Lng: 77.4}, Northeast: LatLng{Lat: 13.1, Lng: 77.8}}, "")
+		assert.ErrorIs(t, err, ErrValidation)
+		assert.EqualError(t, err, "Invalid bounds southwest coordinates 100,77.4: latitude must be within ±90 and longitude within ±180 (latitude and longitude swapped?)")
+	})
 }
 
 func TestReverseGeocode(t *testing.T) {
 	t.Run("Invalid latlng", func(t *testing.T) {
 		olaMap := &OLAMap{}
 		olaMap.HttpService = &MockStruct{}
-		_, err := olaMap.ReverseGeocode("")
+		_, err := olaMap.ReverseGeocode(LatLng{})
 		expectedErr := fmt.Errorf("Missing required query parameters: 'latlng'")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 
 	})
 	t.Run("Invalid OAuth token", func(t *testing.T) {
 		olaMap := &OLAMap{}
-		_, err := olaMap.ReverseGeocode("mock-latlng")
+		_, err := olaMap.ReverseGeocode(mockOrigin)
 		expectedErr := fmt.Errorf("Invalid OAuth token")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 
 	})
 	t.Run("success", func(t *testing.T) {
@@ -119,7 +140,7 @@ func TestReverseGeocode(t *testing.T) {
 		olaMap.Token = "mockToken"
 		mocking := &MockStruct{}
 		olaMap.HttpService = mocking
-		_, err := olaMap.ReverseGeocode("mock-latlng")
+		_, err := olaMap.ReverseGeocode(mockOrigin)
 		assert.Nil(t, nil, err)
 		if mocking.StatusCode != 200 {
 			t.Error("expected : ", 200, "got : ", mocking.StatusCode)
@@ -133,16 +154,16 @@ func TestGetDistanceMatrix(t *testing.T) {
 	t.Run("Invalid origins & destinations", func(t *testing.T) {
 		olaMap := &OLAMap{}
 		olaMap.HttpService = &MockStruct{}
-		_, err := olaMap.GetDistanceMatrix("", "")
+		_, err := olaMap.GetDistanceMatrix(nil, nil)
 		expectedErr := fmt.Errorf("Missing required query parameters: 'origin' and/or 'destination'")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 
 	})
 	t.Run("Invalid OAuth token", func(t *testing.T) {
 		olaMap := &OLAMap{}
-		_, err := olaMap.GetDistanceMatrix("mock-origins", "mock-destinations")
+		_, err := olaMap.GetDistanceMatrix(Path{mockOrigin}, Path{mockDestination})
 		expectedErr := fmt.Errorf("Invalid OAuth token")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 
 	})
 	t.Run("success", func(t *testing.T) {
@@ -150,7 +171,7 @@ func TestGetDistanceMatrix(t *testing.T) {
 		olaMap.Token = "mockToken"
 		mocking := &MockStruct{}
 		olaMap.HttpService = mocking
-		_, err := olaMap.GetDistanceMatrix("mock-origins", "mock-destinations")
+		_, err := olaMap.GetDistanceMatrix(Path{mockOrigin}, Path{mockDestination})
 		assert.Nil(t, nil, err)
 		if mocking.StatusCode != 200 {
 			t.Error("expected : ", 200, "got : ", mocking.StatusCode)
@@ -165,14 +186,14 @@ func TestArrayOfData(t *testing.T) {
 		olaMap.HttpService = &MockStruct{}
 		_, err := olaMap.ArrayOfData("")
 		expectedErr := fmt.Errorf("Missing required query parameters: 'datasetname'")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 
 	})
 	t.Run("Invalid OAuth token", func(t *testing.T) {
 		olaMap := &OLAMap{}
 		_, err := olaMap.ArrayOfData("mock-datasetname")
 		expectedErr := fmt.Errorf("Invalid OAuth token")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 
 	})
 	t.Run("success", func(t *testing.T) {
@@ -196,14 +217,14 @@ func TestGetStyleDetails(t *testing.T) {
 		olaMap.HttpService = &MockStruct{}
 		_, err := olaMap.GetStyleDetails("")
 		expectedErr := fmt.Errorf("Missing required query parameters: 'stylename'")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 
 	})
 	t.Run("Invalid OAuth token", func(t *testing.T) {
 		olaMap := &OLAMap{}
 		_, err := olaMap.GetStyleDetails("mock-stylename")
 		expectedErr := fmt.Errorf("Invalid OAuth token")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 
 	})
 	t.Run("success", func(t *testing.T) {
@@ -226,7 +247,7 @@ func TestGetMapStyle(t *testing.T) {
 		olaMap := &OLAMap{}
 		_, err := olaMap.GetMapStyle()
 		expectedErr := fmt.Errorf("Invalid OAuth token")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 
 	})
 
@@ -250,14 +271,14 @@ func TestGetPlaceDetail(t *testing.T) {
 		olaMap.HttpService = &MockStruct{}
 		_, err := olaMap.GetPlaceDetail("")
 		expectedErr := fmt.Errorf("Missing required query parameters: 'placeid'")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 
 	})
 	t.Run("Invalid OAuth token", func(t *testing.T) {
 		olaMap := &OLAMap{}
 		_, err := olaMap.GetPlaceDetail("mock-placeid")
 		expectedErr := fmt.Errorf("Invalid OAuth token")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 
 	})
 	t.Run("success", func(t *testing.T) {
@@ -279,18 +300,18 @@ func TestGetNearBySearch(t *testing.T) {
 	t.Run("Invalid 'layers' and 'location'", func(t *testing.T) {
 		olaMap := &OLAMap{}
 		olaMap.HttpService = &MockStruct{}
-		_, err := olaMap.GetNearBySearch(NearBySearch{Layers: "", Location: ""})
+		_, err := olaMap.GetNearBySearch(NearBySearch{Layers: ""})
 		expectedErr := fmt.Errorf("Missing required query parameters: 'layers' and/or 'location'")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 
 	})
 	t.Run("Invalid OAuth token", func(t *testing.T) {
 		olaMap := &OLAMap{}
 		olaMap.HttpService = &MockStruct{}
 		fmt.Printf("Mocking = %+v", olaMap)
-		_, err := olaMap.GetNearBySearch(NearBySearch{Layers: "Mock-Layers", Location: "Mock-Location"})
+		_, err := olaMap.GetNearBySearch(NearBySearch{Layers: "Mock-Layers", Location: mockOrigin})
 		expectedErr := fmt.Errorf("Invalid OAuth token")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 
 	})
 	t.Run("success", func(t *testing.T) {
@@ -298,7 +319,7 @@ func TestGetNearBySearch(t *testing.T) {
 		olaMap.Token = "mockToken"
 		mocking := &MockStruct{}
 		olaMap.HttpService = mocking
-		_, err := olaMap.GetNearBySearch(NearBySearch{Layers: "Mock-Layers", Location: "Mock-Location"})
+		_, err := olaMap.GetNearBySearch(NearBySearch{Layers: "Mock-Layers", Location: mockOrigin})
 		assert.Nil(t, nil, err)
 		if mocking.StatusCode != 200 {
 			t.Error("expected : ", 200, "got : ", mocking.StatusCode)
@@ -313,14 +334,14 @@ func TestGetTextSearch(t *testing.T) {
 		olaMap.HttpService = &MockStruct{}
 		_, err := olaMap.GetTextSearch(TextSearch{Input: ""})
 		expectedErr := fmt.Errorf("Missing required query parameters: 'input'")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 
 	})
 	t.Run("Invalid OAuth token", func(t *testing.T) {
 		olaMap := &OLAMap{}
 		_, err := olaMap.GetTextSearch(TextSearch{Input: "mock-input"})
 		expectedErr := fmt.Errorf("Invalid OAuth token")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 
 	})
 	t.Run("success", func(t *testing.T) {
@@ -341,16 +362,16 @@ func TestSnapToRoad(t *testing.T) {
 	t.Run("Invalid points", func(t *testing.T) {
 		olaMap := &OLAMap{}
 		olaMap.HttpService = &MockStruct{}
-		_, err := olaMap.GetSnapToRoad("", "")
+		_, err := olaMap.GetSnapToRoad(nil, "")
 		expectedErr := fmt.Errorf("Missing required query parameters: 'points'")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 
 	})
 	t.Run("Invalid OAuth token", func(t *testing.T) {
 		olaMap := &OLAMap{}
-		_, err := olaMap.GetSnapToRoad("mock-points", "mock-enhancepath")
+		_, err := olaMap.GetSnapToRoad(Path{mockOrigin, mockDestination}, "mock-enhancepath")
 		expectedErr := fmt.Errorf("Invalid OAuth token")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 
 	})
 	t.Run("success", func(t *testing.T) {
@@ -358,7 +379,7 @@ func TestSnapToRoad(t *testing.T) {
 		olaMap.Token = "mockToken"
 		mocking := &MockStruct{}
 		olaMap.HttpService = mocking
-		_, err := olaMap.GetSnapToRoad("mock-points", "mock-enhancepath")
+		_, err := olaMap.GetSnapToRoad(Path{mockOrigin, mockDestination}, "mock-enhancepath")
 		assert.Nil(t, nil, err)
 		if mocking.StatusCode != 200 {
 			t.Error("expected : ", 200, "got : ", mocking.StatusCode)
@@ -372,16 +393,16 @@ func TestNearestRoad(t *testing.T) {
 	t.Run("Invalid points or radius", func(t *testing.T) {
 		olaMap := &OLAMap{}
 		olaMap.HttpService = &MockStruct{}
-		_, err := olaMap.GetNearestRoads("", "")
+		_, err := olaMap.GetNearestRoads(nil, "")
 		expectedErr := fmt.Errorf("Missing required query parameters: 'points' and/or 'radius'")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 
 	})
 	t.Run("Invalid OAuth token", func(t *testing.T) {
 		olaMap := &OLAMap{}
-		_, err := olaMap.GetNearestRoads("mock-points", "mock-radius")
+		_, err := olaMap.GetNearestRoads(Path{mockOrigin, mockDestination}, "mock-radius")
 		expectedErr := fmt.Errorf("Invalid OAuth token")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 
 	})
 	t.Run("success", func(t *testing.T) {
@@ -389,7 +410,7 @@ func TestNearestRoad(t *testing.T) {
 		olaMap.Token = "mockToken"
 		mocking := &MockStruct{}
 		olaMap.HttpService = mocking
-		_, err := olaMap.GetNearestRoads("mock-points", "mock-radius")
+		_, err := olaMap.GetNearestRoads(Path{mockOrigin, mockDestination}, "mock-radius")
 		assert.Nil(t, nil, err)
 		if mocking.StatusCode != 200 {
 			t.Error("expected : ", 200, "got : ", mocking.StatusCode)
@@ -413,7 +434,7 @@ func TestGetImageCenter(t *testing.T) {
 
 		_, err := olaMap.GetStaticMapImageCenter(mapImageCenter)
 		expectedErr := fmt.Errorf("Missing required query parameters: 'stylename' or 'longitude' or 'latitude' or 'zoomlevel' or 'width' or 'height' or 'format'")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 
 	})
 
@@ -432,7 +453,7 @@ func TestGetImageCenter(t *testing.T) {
 
 		_, err := olaMap.GetStaticMapImageCenter(mapImageCenter)
 		expectedErr := fmt.Errorf("Invalid zoom level value")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 
 	})
 
@@ -451,7 +472,7 @@ func TestGetImageCenter(t *testing.T) {
 
 		_, err := olaMap.GetStaticMapImageCenter(mapImageCenter)
 		expectedErr := fmt.Errorf("Invalid image width value")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 
 	})
 
@@ -470,7 +491,7 @@ func TestGetImageCenter(t *testing.T) {
 
 		_, err := olaMap.GetStaticMapImageCenter(mapImageCenter)
 		expectedErr := fmt.Errorf("Invalid image height value")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 
 	})
 
@@ -489,7 +510,7 @@ func TestGetImageCenter(t *testing.T) {
 
 		_, err := olaMap.GetStaticMapImageCenter(mapImageCenter)
 		expectedErr := fmt.Errorf("Invalid OAuth token")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 
 	})
 }
@@ -511,7 +532,7 @@ func TestGetImageBounded(t *testing.T) {
 
 		_, err := olaMap.GetStaticMapImageBounded(mapImageBounded)
 		expectedErr := fmt.Errorf("Missing required query parameters: 'styleName' or 'minXStr' or 'minYStr' or 'maxXStr' or 'maxYStr' or 'imageWidthStr' or 'imageHeightStr' or 'imageFormat'")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 
 	})
 
@@ -531,7 +552,7 @@ func TestGetImageBounded(t *testing.T) {
 
 		_, err := olaMap.GetStaticMapImageBounded(mapImageBounded)
 		expectedErr := fmt.Errorf("Invalid min_x value")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 
 	})
 
@@ -551,7 +572,7 @@ func TestGetImageBounded(t *testing.T) {
 
 		_, err := olaMap.GetStaticMapImageBounded(mapImageBounded)
 		expectedErr := fmt.Errorf("Invalid min_y value")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 
 	})
 
@@ -571,7 +592,7 @@ func TestGetImageBounded(t *testing.T) {
 
 		_, err := olaMap.GetStaticMapImageBounded(mapImageBounded)
 		expectedErr := fmt.Errorf("Invalid max_x value")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 
 	})
 
@@ -591,7 +612,7 @@ func TestGetImageBounded(t *testing.T) {
 
 		_, err := olaMap.GetStaticMapImageBounded(mapImageBounded)
 		expectedErr := fmt.Errorf("Invalid max_y value")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 
 	})
 
@@ -611,7 +632,7 @@ func TestGetImageBounded(t *testing.T) {
 
 		_, err := olaMap.GetStaticMapImageBounded(mapImageBounded)
 		expectedErr := fmt.Errorf("Invalid image height value")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 
 	})
 
@@ -631,7 +652,7 @@ func TestGetImageBounded(t *testing.T) {
 
 		_, err := olaMap.GetStaticMapImageBounded(mapImageBounded)
 		expectedErr := fmt.Errorf("Invalid image width value")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 
 	})
 
@@ -651,7 +672,7 @@ func TestGetImageBounded(t *testing.T) {
 
 		_, err := olaMap.GetStaticMapImageBounded(mapImageBounded)
 		expectedErr := fmt.Errorf("Invalid OAuth token")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 	})
 }
 
@@ -668,7 +689,7 @@ func TestStaticMapImage(t *testing.T) {
 
 		_, err := olaMap.StaticMapImage(mapImage)
 		expectedErr := fmt.Errorf("Missing required query parameters: 'stylename' or 'imagewidth' or 'imageheight' or 'imageformat' or path")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 
 	})
 
@@ -686,7 +707,7 @@ func TestStaticMapImage(t *testing.T) {
 
 		_, err := olaMap.StaticMapImage(mapImage)
 		expectedErr := fmt.Errorf("Invalid image width value")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 
 	})
 
@@ -704,7 +725,7 @@ func TestStaticMapImage(t *testing.T) {
 
 		_, err := olaMap.StaticMapImage(mapImage)
 		expectedErr := fmt.Errorf("Invalid image height value")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 
 	})
 	t.Run("InValid Token", func(t *testing.T) {
@@ -721,7 +742,68 @@ func TestStaticMapImage(t *testing.T) {
 
 		_, err := olaMap.StaticMapImage(mapImage)
 		expectedErr := fmt.Errorf("Invalid OAuth token")
-		assert.Exactly(t, err, expectedErr)
+		assert.EqualError(t, err, expectedErr.Error())
 
 	})
 }
+
+func TestGetDirectionsWithContext(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		olaMap := &OLAMap{}
+		olaMap.Token = "mockToken"
+		mocking := &MockStruct{}
+		olaMap.HttpService = mocking
+		_, err := olaMap.GetDirectionsWithContext(context.Background(), mockOrigin, mockDestination)
+		assert.Nil(t, err)
+		assert.Equal(t, DirectionResponse, mocking.MockBody)
+	})
+}
+
+func TestGetDirectionsWithOptions(t *testing.T) {
+	t.Run("Invalid origin & destination", func(t *testing.T) {
+		olaMap := &OLAMap{}
+		_, err := olaMap.GetDirectionsWithOptions(context.Background(), DirectionsRequest{Origin: LatLng{Lat: 12.93, Lng: 77.61}})
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+	t.Run("success", func(t *testing.T) {
+		mocking := &fixtureService{body: `{"status":"SUCCESS","routes":[{"summary":"route"}]}`}
+		olaMap := &OLAMap{Token: "mockToken", HttpService: mocking}
+		directionsRequest := DirectionsRequest{
+			Origin:            LatLng{Lat: 12.93, Lng: 77.61},
+			Destination:       LatLng{Lat: 12.97, Lng: 77.59},
+			Waypoints:         Path{{Lat: 12.95, Lng: 77.6}, {Lat: 12.96, Lng: 77.62}},
+			OptimizeWaypoints: true,
+			Mode:              TravelModeWalking,
+			Alternatives:      true,
+			AvoidTolls:        true,
+			AvoidFerries:      true,
+			OmitSteps:         true,
+			Overview:          OverviewSimplified,
+			Language:          "hi",
+			TrafficMetadata:   true,
+		}
+		directions, err := olaMap.GetDirectionsWithOptions(context.Background(), directionsRequest)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, directions.Routes)
+
+		parsed, _ := url.Parse(mocking.urls[0])
+		query := parsed.Query()
+		assert.Equal(t, "12.93,77.61", query.Get("origin"))
+		assert.Equal(t, "12.97,77.59", query.Get("destination"))
+		assert.Equal(t, "optimize:true|12.95,77.6|12.96,77.62", query.Get("waypoints"))
+		assert.Equal(t, "walking", query.Get("mode"))
+		assert.Equal(t, "true", query.Get("alternatives"))
+		assert.Equal(t, "tolls|ferries", query.Get("avoid"))
+		assert.Equal(t, "false", query.Get("steps"))
+		assert.Equal(t, "simplified", query.Get("overview"))
+		assert.Equal(t, "hi", query.Get("language"))
+		assert.Equal(t, "true", query.Get("traffic_metadata"))
+	})
+	t.Run("defaults", func(t *testing.T) {
+		mocking := &fixtureService{body: `{"status":"SUCCESS","routes":[{"summary":"route"}]}`}
+		olaMap := &OLAMap{Token: "mockToken", HttpService: mocking}
+		_, err := olaMap.GetDirections(LatLng{Lat: 12.93, Lng: 77.61}, LatLng{Lat: 12.97, Lng: 77.59})
+		assert.Nil(t, err)
+		assert.Equal(t, "https://api.olamaps.io/routing/v1/directions?origin=12.93%2C77.61&destination=12.97%2C77.59", mocking.urls[0])
+	})
+}
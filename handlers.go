@@ -1,376 +1,558 @@
 package golamap
 
 import (
-	"errors"
+	"context"
 	"fmt"
-	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
-
-	"github.com/google/uuid"
 )
 
 // Get directions
-func (o *OLAMap) GetDirections(origin, destination string) (interface{}, error) {
-	if origin == "" || destination == "" {
-		return nil, errors.New("Missing required query parameters: 'origin' and/or 'destination'")
+func (o *OLAMap) GetDirections(origin, destination LatLng) (Directions, error) {
+	return o.GetDirectionsWithContext(context.Background(), origin, destination)
+}
+
+// GetDirectionsWithContext is GetDirections bound to ctx, which is carried to the outgoing request
+func (o *OLAMap) GetDirectionsWithContext(ctx context.Context, origin, destination LatLng) (Directions, error) {
+	return o.GetDirectionsWithOptions(ctx, DirectionsRequest{Origin: origin, Destination: destination})
+}
+
+// GetDirectionsWithOptions gets directions with waypoints, travel mode and
+// the other options of DirectionsRequest
+func (o *OLAMap) GetDirectionsWithOptions(ctx context.Context, directionsRequest DirectionsRequest) (Directions, error) {
+	if directionsRequest.Origin.IsZero() || directionsRequest.Destination.IsZero() {
+		return Directions{}, validationError("Missing required query parameters: 'origin' and/or 'destination'")
+	}
+	if err := validateLocation("origin", directionsRequest.Origin); err != nil {
+		return Directions{}, err
+	}
+	if err := validateLocation("destination", directionsRequest.Destination); err != nil {
+		return Directions{}, err
+	}
+	if err := validatePath("waypoint", directionsRequest.Waypoints); err != nil {
+		return Directions{}, err
 	}
 
-	oauthToken := o.Token
-	if oauthToken == "" {
-		return nil, errors.New("Invalid OAuth token")
+	cred, err := o.credential(ctx)
+	if err != nil {
+		return Directions{}, err
 	}
 
-	url := fmt.Sprintf(DirectionsURL, origin, destination)
+	apiURL := o.endpointURL(EndpointDirections, url.QueryEscape(directionsRequest.Origin.String()), url.QueryEscape(directionsRequest.Destination.String()))
+	if options := directionsRequest.options(); len(options) > 0 {
+		apiURL += "&" + options.Encode()
+	}
 
 	var apiResponse Directions
 
 	// Make external request
-	err := o.HttpService.SendOlaMapRequest("POST", url, o.RequestId, oauthToken, &apiResponse)
+	err = o.send(ctx, EndpointDirections, "POST", apiURL, cred, &apiResponse)
 	if err != nil {
-		return nil, errors.New("failed to send request to Olamaps API")
+		return Directions{}, fmt.Errorf("failed to send request to Olamaps API: %w", err)
 	}
 
 	return apiResponse, nil
 }
 
+// options encodes the optional parameters of a directions request
+func (d DirectionsRequest) options() url.Values {
+	options := url.Values{}
+	if len(d.Waypoints) > 0 {
+		waypoints := d.Waypoints.String()
+		if d.OptimizeWaypoints {
+			waypoints = "optimize:true|" + waypoints
+		}
+		options.Set("waypoints", waypoints)
+	}
+	if d.Mode != "" {
+		options.Set("mode", string(d.Mode))
+	}
+	if d.Alternatives {
+		options.Set("alternatives", "true")
+	}
+
+	var avoid []string
+	if d.AvoidTolls {
+		avoid = append(avoid, "tolls")
+	}
+	if d.AvoidHighways {
+		avoid = append(avoid, "highways")
+	}
+	if d.AvoidFerries {
+		avoid = append(avoid, "ferries")
+	}
+	if len(avoid) > 0 {
+		options.Set("avoid", strings.Join(avoid, "|"))
+	}
+
+	if d.OmitSteps {
+		options.Set("steps", "false")
+	}
+	if d.Overview != "" {
+		options.Set("overview", string(d.Overview))
+	}
+	if d.Language != "" {
+		options.Set("language", d.Language)
+	}
+	if d.TrafficMetadata {
+		options.Set("traffic_metadata", "true")
+	}
+
+	return options
+}
+
 // PlaceAutoComplete
-func (o *OLAMap) PlaceAutoComplete(input string) (interface{}, error) {
+func (o *OLAMap) PlaceAutoComplete(input string) (AutoComplete, error) {
+	return o.PlaceAutoCompleteWithContext(context.Background(), input)
+}
+
+// PlaceAutoCompleteWithContext is PlaceAutoComplete bound to ctx, which is carried to the outgoing request
+func (o *OLAMap) PlaceAutoCompleteWithContext(ctx context.Context, input string) (AutoComplete, error) {
 	if input == "" {
-		return nil, errors.New("Missing required query parameters: 'input'")
+		return AutoComplete{}, validationError("Missing required query parameters: 'input'")
 	}
 
-	oauthToken := o.Token
-	if oauthToken == "" {
-		return nil, errors.New("Invalid OAuth token")
+	cred, err := o.credential(ctx)
+	if err != nil {
+		return AutoComplete{}, err
 	}
 
 	// Construct the URL for the Olamaps API request
-	url := fmt.Sprintf(PlaceAutoCompleteURL, input)
+	url := o.endpointURL(EndpointPlaceAutoComplete, input)
 
 	var apiResponse AutoComplete
 
 	// Make the external request
-	err := o.HttpService.SendOlaMapRequest("GET", url, o.RequestId, oauthToken, &apiResponse)
+	err = o.send(ctx, EndpointPlaceAutoComplete, "GET", url, cred, &apiResponse)
 	if err != nil {
-		return nil, errors.New("failed to send request to Olamaps API")
+		return AutoComplete{}, fmt.Errorf("failed to send request to Olamaps API: %w", err)
 	}
 
 	return apiResponse, nil
 }
 
-// GeoCode
-func (o *OLAMap) GeoCode(address, bounds, language string) (interface{}, error) {
+// GeoCode converts an address into coordinates. Results are restricted to
+// bounds unless it is the zero Bounds.
+func (o *OLAMap) GeoCode(address string, bounds Bounds, language string) (ForwardGecode, error) {
+	return o.GeoCodeWithContext(context.Background(), address, bounds, language)
+}
+
+// GeoCodeWithContext is GeoCode bound to ctx, which is carried to the outgoing request
+func (o *OLAMap) GeoCodeWithContext(ctx context.Context, address string, bounds Bounds, language string) (ForwardGecode, error) {
 	if address == "" {
-		return nil, errors.New("Missing required query parameters: 'address'")
+		return ForwardGecode{}, validationError("Missing required query parameters: 'address'")
+	}
+	if !bounds.IsZero() {
+		if err := validateLocation("bounds southwest", bounds.Southwest); err != nil {
+			return ForwardGecode{}, err
+		}
+		if err := validateLocation("bounds northeast", bounds.Northeast); err != nil {
+			return ForwardGecode{}, err
+		}
 	}
 
-	oauthToken := o.Token
-	if oauthToken == "" {
-		return nil, errors.New("Invalid OAuth token")
+	cred, err := o.credential(ctx)
+	if err != nil {
+		return ForwardGecode{}, err
 	}
 
 	// Construct the URL for the Olamaps API request
-	url := fmt.Sprintf(GeoCodeURL, address, bounds, language)
+	apiURL := o.endpointURL(EndpointGeoCode, address, url.QueryEscape(bounds.String()), language)
 
 	var apiResponse ForwardGecode
 
 	// Make the external request
-	err := o.HttpService.SendOlaMapRequest("GET", url, o.RequestId, oauthToken, &apiResponse)
+	err = o.send(ctx, EndpointGeoCode, "GET", apiURL, cred, &apiResponse)
 	if err != nil {
-		return nil, errors.New("failed to send request to Olamaps API")
+		return ForwardGecode{}, fmt.Errorf("failed to send request to Olamaps API: %w", err)
 	}
 
 	return apiResponse, nil
 }
 
 // ReverseGeocode
-func (o *OLAMap) ReverseGeocode(latlng string) (interface{}, error) {
-	if latlng == "" {
-		return nil, errors.New("Missing required query parameters: 'latlng'")
+func (o *OLAMap) ReverseGeocode(latlng LatLng) (ReverseGecode, error) {
+	return o.ReverseGeocodeWithContext(context.Background(), latlng)
+}
+
+// ReverseGeocodeWithContext is ReverseGeocode bound to ctx, which is carried to the outgoing request
+func (o *OLAMap) ReverseGeocodeWithContext(ctx context.Context, latlng LatLng) (ReverseGecode, error) {
+	if latlng.IsZero() {
+		return ReverseGecode{}, validationError("Missing required query parameters: 'latlng'")
+	}
+	if err := validateLocation("latlng", latlng); err != nil {
+		return ReverseGecode{}, err
 	}
 
-	oauthToken := o.Token
-	if oauthToken == "" {
-		return nil, errors.New("Invalid OAuth token")
+	if o.reverseGeocodeCache != nil {
+		if cached, ok := o.reverseGeocodeCache.Get(latlng); ok {
+			return cached, nil
+		}
+	}
+
+	cred, err := o.credential(ctx)
+	if err != nil {
+		return ReverseGecode{}, err
 	}
 
 	// Construct the URL for the API request
-	urlWithParams := fmt.Sprintf(ReverseGeocodeURL, url.QueryEscape(latlng))
+	urlWithParams := o.endpointURL(EndpointReverseGeocode, url.QueryEscape(latlng.String()))
 
 	var apiResponse ReverseGecode
 
 	// Make the external request
-	err := o.HttpService.SendOlaMapRequest("GET", urlWithParams, o.RequestId, oauthToken, &apiResponse)
+	err = o.send(ctx, EndpointReverseGeocode, "GET", urlWithParams, cred, &apiResponse)
 	if err != nil {
-		return nil, errors.New("failed to send request to Olamaps API")
+		return ReverseGecode{}, fmt.Errorf("failed to send request to Olamaps API: %w", err)
+	}
+
+	if o.reverseGeocodeCache != nil {
+		o.reverseGeocodeCache.Set(latlng, apiResponse)
 	}
 
 	return apiResponse, nil
 }
 
 // GetDistanceMatrix
-func (o *OLAMap) GetDistanceMatrix(origins, destinations string) (interface{}, error) {
-	if origins == "" || destinations == "" {
-		return nil, errors.New("Missing required query parameters: 'origin' and/or 'destination'")
+func (o *OLAMap) GetDistanceMatrix(origins, destinations Path) (DistanceMatrix, error) {
+	return o.GetDistanceMatrixWithContext(context.Background(), origins, destinations)
+}
+
+// GetDistanceMatrixWithContext is GetDistanceMatrix bound to ctx, which is carried to the outgoing request
+func (o *OLAMap) GetDistanceMatrixWithContext(ctx context.Context, origins, destinations Path) (DistanceMatrix, error) {
+	if len(origins) == 0 || len(destinations) == 0 {
+		return DistanceMatrix{}, validationError("Missing required query parameters: 'origin' and/or 'destination'")
+	}
+	if err := validatePath("origin", origins); err != nil {
+		return DistanceMatrix{}, err
+	}
+	if err := validatePath("destination", destinations); err != nil {
+		return DistanceMatrix{}, err
 	}
 
-	oauthToken := o.Token
-	if oauthToken == "" {
-		return nil, errors.New("Invalid OAuth token")
+	cred, err := o.credential(ctx)
+	if err != nil {
+		return DistanceMatrix{}, err
 	}
 
 	// Construct the URL for the Olamaps API request
-	url := fmt.Sprintf(DistanceMatrixURL,
-		url.QueryEscape(origins), url.QueryEscape(destinations))
+	url := o.endpointURL(EndpointDistanceMatrix,
+		url.QueryEscape(origins.String()), url.QueryEscape(destinations.String()))
 
 	var apiResponse DistanceMatrix
 
 	// Make the external request
-	err := o.HttpService.SendOlaMapRequest("GET", url, o.RequestId, oauthToken, &apiResponse)
+	err = o.send(ctx, EndpointDistanceMatrix, "GET", url, cred, &apiResponse)
 	if err != nil {
-		return nil, errors.New("failed to send request to Olamaps API")
+		return DistanceMatrix{}, fmt.Errorf("failed to send request to Olamaps API: %w", err)
 	}
 
 	return apiResponse, nil
 }
 
 // ArrayOfData
-func (o *OLAMap) ArrayOfData(datasetName string) (interface{}, error) {
+func (o *OLAMap) ArrayOfData(datasetName string) (ArrayOfData, error) {
+	return o.ArrayOfDataWithContext(context.Background(), datasetName)
+}
+
+// ArrayOfDataWithContext is ArrayOfData bound to ctx, which is carried to the outgoing request
+func (o *OLAMap) ArrayOfDataWithContext(ctx context.Context, datasetName string) (ArrayOfData, error) {
 	if datasetName == "" {
-		return nil, errors.New("Missing required query parameters: 'datasetname'")
+		return ArrayOfData{}, validationError("Missing required query parameters: 'datasetname'")
 	}
 
-	oauthToken := o.Token
-	if oauthToken == "" {
-		return nil, errors.New("Invalid OAuth token")
+	cred, err := o.credential(ctx)
+	if err != nil {
+		return ArrayOfData{}, err
 	}
 
 	// Construct the URL for the Olamaps API request
-	apiURL := fmt.Sprintf(ArrayOfDataURL, url.QueryEscape(datasetName))
+	apiURL := o.endpointURL(EndpointArrayOfData, url.QueryEscape(datasetName))
 
 	var apiResponse ArrayOfData
 
 	// Make the external request
-	err := o.HttpService.SendOlaMapRequest("GET", apiURL, o.RequestId, oauthToken, &apiResponse)
+	err = o.send(ctx, EndpointArrayOfData, "GET", apiURL, cred, &apiResponse)
 	if err != nil {
-		return nil, errors.New("failed to send request to Olamaps API")
+		return ArrayOfData{}, fmt.Errorf("failed to send request to Olamaps API: %w", err)
 	}
 
+	apiResponse.addAPIKey(cred)
+
 	return apiResponse, nil
 }
 
 // GetStyleDetails
-func (o *OLAMap) GetStyleDetails(styleName string) (interface{}, error) {
+func (o *OLAMap) GetStyleDetails(styleName string) (VectorStyleDetails, error) {
+	return o.GetStyleDetailsWithContext(context.Background(), styleName)
+}
+
+// GetStyleDetailsWithContext is GetStyleDetails bound to ctx, which is carried to the outgoing request
+func (o *OLAMap) GetStyleDetailsWithContext(ctx context.Context, styleName string) (VectorStyleDetails, error) {
 	if styleName == "" {
-		return nil, errors.New("Missing required query parameters: 'stylename'")
+		return VectorStyleDetails{}, validationError("Missing required query parameters: 'stylename'")
 	}
 
-	oauthToken := o.Token
-	if oauthToken == "" {
-		return nil, errors.New("Invalid OAuth token")
+	cred, err := o.credential(ctx)
+	if err != nil {
+		return VectorStyleDetails{}, err
 	}
 
 	// Construct the URL for the Olamaps API request
-	apiURL := fmt.Sprintf(StyleDetailsURL, url.QueryEscape(styleName))
+	apiURL := o.endpointURL(EndpointStyleDetails, url.QueryEscape(styleName))
 
 	var apiResponse VectorStyleDetails
 
 	// Make the external request
-	err := o.HttpService.SendOlaMapRequest("GET", apiURL, o.RequestId, oauthToken, &apiResponse)
+	err = o.send(ctx, EndpointStyleDetails, "GET", apiURL, cred, &apiResponse)
 	if err != nil {
-		return nil, errors.New("failed to send request to Olamaps API")
+		return VectorStyleDetails{}, fmt.Errorf("failed to send request to Olamaps API: %w", err)
 	}
 
+	apiResponse.addAPIKey(cred)
+
 	return apiResponse, nil
 }
 
 // GetMapStyle
-func (o *OLAMap) GetMapStyle() (interface{}, error) {
-	oauthToken := o.Token
-	if oauthToken == "" {
-		return nil, errors.New("Invalid OAuth token")
+func (o *OLAMap) GetMapStyle() ([]VectorMapStyle, error) {
+	return o.GetMapStyleWithContext(context.Background())
+}
+
+// GetMapStyleWithContext is GetMapStyle bound to ctx, which is carried to the outgoing request
+func (o *OLAMap) GetMapStyleWithContext(ctx context.Context) ([]VectorMapStyle, error) {
+	cred, err := o.credential(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	apiURL := MapStyleURL
+	apiURL := o.endpointURL(EndpointMapStyle)
 
 	var apiResponse []VectorMapStyle
 
 	// Make the external request
-	err := o.HttpService.SendOlaMapRequest("GET", apiURL, o.RequestId, oauthToken, &apiResponse)
+	err = o.send(ctx, EndpointMapStyle, "GET", apiURL, cred, &apiResponse)
 	if err != nil {
-		return nil, errors.New("failed to send request to Olamaps API")
+		return nil, fmt.Errorf("failed to send request to Olamaps API: %w", err)
 	}
 
+	addAPIKeyToStyles(apiResponse, cred)
+
 	return apiResponse, nil
 }
 
 // GetPlaceDetail
-func (o *OLAMap) GetPlaceDetail(placeID string) (interface{}, error) {
+func (o *OLAMap) GetPlaceDetail(placeID string) (PlaceDetail, error) {
+	return o.GetPlaceDetailWithContext(context.Background(), placeID)
+}
+
+// GetPlaceDetailWithContext is GetPlaceDetail bound to ctx, which is carried to the outgoing request
+func (o *OLAMap) GetPlaceDetailWithContext(ctx context.Context, placeID string) (PlaceDetail, error) {
 	if placeID == "" {
-		return nil, errors.New("Missing required query parameters: 'placeid'")
+		return PlaceDetail{}, validationError("Missing required query parameters: 'placeid'")
 	}
 
-	oauthToken := o.Token
-	if oauthToken == "" {
-		return nil, errors.New("Invalid OAuth token")
+	cred, err := o.credential(ctx)
+	if err != nil {
+		return PlaceDetail{}, err
 	}
 
-	apiURL := fmt.Sprintf(PlaceDetailURL, placeID)
+	apiURL := o.endpointURL(EndpointPlaceDetail, placeID)
 
 	var apiResponse PlaceDetail
 
 	// Make the external request
-	err := o.HttpService.SendOlaMapRequest("GET", apiURL, o.RequestId, oauthToken, &apiResponse)
+	err = o.send(ctx, EndpointPlaceDetail, "GET", apiURL, cred, &apiResponse)
 	if err != nil {
-		return nil, errors.New("failed to send request to Olamaps API")
+		return PlaceDetail{}, fmt.Errorf("failed to send request to Olamaps API: %w", err)
 	}
 
 	return apiResponse, nil
 }
 
 // GetNearBySearch
-func (o *OLAMap) GetNearBySearch(nearBySearch NearBySearch) (interface{}, error) {
-	if nearBySearch.Layers == "" || nearBySearch.Location == "" {
-		return nil, errors.New("Missing required query parameters: 'layers' and/or 'location'")
+func (o *OLAMap) GetNearBySearch(nearBySearch NearBySearch) (NearBySearchResponse, error) {
+	return o.GetNearBySearchWithContext(context.Background(), nearBySearch)
+}
+
+// GetNearBySearchWithContext is GetNearBySearch bound to ctx, which is carried to the outgoing request
+func (o *OLAMap) GetNearBySearchWithContext(ctx context.Context, nearBySearch NearBySearch) (NearBySearchResponse, error) {
+	if nearBySearch.Layers == "" || nearBySearch.Location.IsZero() {
+		return NearBySearchResponse{}, validationError("Missing required query parameters: 'layers' and/or 'location'")
+	}
+	if err := validateLocation("location", nearBySearch.Location); err != nil {
+		return NearBySearchResponse{}, err
 	}
 
-	oauthToken := o.Token
-	if oauthToken == "" {
-		return nil, errors.New("Invalid OAuth token")
+	cred, err := o.credential(ctx)
+	if err != nil {
+		return NearBySearchResponse{}, err
 	}
 
-	apiURL := fmt.Sprintf(NearBySearchURL, nearBySearch.Layers, nearBySearch.Location, nearBySearch.Types, nearBySearch.Radius, nearBySearch.Strictbounds, nearBySearch.WithCentroid, nearBySearch.Limit)
+	apiURL := o.endpointURL(EndpointNearBySearch, nearBySearch.Layers, nearBySearch.Location.String(), nearBySearch.Types, nearBySearch.Radius, nearBySearch.Strictbounds, nearBySearch.WithCentroid, nearBySearch.Limit)
 
 	var apiResponse NearBySearchResponse
 
 	// Make the external request
-	err := o.HttpService.SendOlaMapRequest("GET", apiURL, o.RequestId, oauthToken, &apiResponse)
+	err = o.send(ctx, EndpointNearBySearch, "GET", apiURL, cred, &apiResponse)
 	if err != nil {
-		return nil, errors.New("failed to send request to Olamaps API")
+		return NearBySearchResponse{}, fmt.Errorf("failed to send request to Olamaps API: %w", err)
 	}
 
 	return apiResponse, nil
 }
 
 // GetTextSearch
-func (o *OLAMap) GetTextSearch(textSearch TextSearch) (interface{}, error) {
+func (o *OLAMap) GetTextSearch(textSearch TextSearch) (TextBySearch, error) {
+	return o.GetTextSearchWithContext(context.Background(), textSearch)
+}
+
+// GetTextSearchWithContext is GetTextSearch bound to ctx, which is carried to the outgoing request
+func (o *OLAMap) GetTextSearchWithContext(ctx context.Context, textSearch TextSearch) (TextBySearch, error) {
 	// Extract query parameters
 	if textSearch.Input == "" {
-		return nil, errors.New("Missing required query parameters: 'input'")
+		return TextBySearch{}, validationError("Missing required query parameters: 'input'")
+	}
+	location := ""
+	if !textSearch.Location.IsZero() {
+		if err := validateLocation("location", textSearch.Location); err != nil {
+			return TextBySearch{}, err
+		}
+		location = textSearch.Location.String()
 	}
 
-	oauthToken := o.Token
-	if oauthToken == "" {
-		return nil, errors.New("Invalid OAuth token")
+	cred, err := o.credential(ctx)
+	if err != nil {
+		return TextBySearch{}, err
 	}
 
 	// Construct the API URL
-	apiURL := fmt.Sprintf(TextSearchURL, url.QueryEscape(textSearch.Input), textSearch.Location, textSearch.Radius, textSearch.Types, textSearch.Size)
+	apiURL := o.endpointURL(EndpointTextSearch, url.QueryEscape(textSearch.Input), location, textSearch.Radius, textSearch.Types, textSearch.Size)
 
 	var apiResponse TextBySearch
 
 	// Make the external request
-	err := o.HttpService.SendOlaMapRequest("GET", apiURL, o.RequestId, oauthToken, &apiResponse)
+	err = o.send(ctx, EndpointTextSearch, "GET", apiURL, cred, &apiResponse)
 	if err != nil {
-		return nil, errors.New("failed to send request to Olamaps API")
+		return TextBySearch{}, fmt.Errorf("failed to send request to Olamaps API: %w", err)
 	}
 
 	return apiResponse, nil
 }
 
 // GetSnapToRoad
-func (o *OLAMap) GetSnapToRoad(points, enhancePath string) (interface{}, error) {
-	if points == "" {
-		return nil, errors.New("Missing required query parameters: 'points'")
+func (o *OLAMap) GetSnapToRoad(points Path, enhancePath string) (SnapToRoad, error) {
+	return o.GetSnapToRoadWithContext(context.Background(), points, enhancePath)
+}
+
+// GetSnapToRoadWithContext is GetSnapToRoad bound to ctx, which is carried to the outgoing request
+func (o *OLAMap) GetSnapToRoadWithContext(ctx context.Context, points Path, enhancePath string) (SnapToRoad, error) {
+	if len(points) == 0 {
+		return SnapToRoad{}, validationError("Missing required query parameters: 'points'")
+	}
+	if err := validatePath("point", points); err != nil {
+		return SnapToRoad{}, err
 	}
 
-	oauthToken := o.Token
-	if oauthToken == "" {
-		return nil, errors.New("Invalid OAuth token")
+	cred, err := o.credential(ctx)
+	if err != nil {
+		return SnapToRoad{}, err
 	}
 
 	// Build URL
-	apiURL := fmt.Sprintf(SnapToRoadURL, url.Values{
-		"points":      {points},
+	apiURL := o.endpointURL(EndpointSnapToRoad, url.Values{
+		"points":      {points.String()},
 		"enhancePath": {enhancePath},
 	}.Encode())
 
 	// Make the external request
 	var apiResponse SnapToRoad
-	err := o.HttpService.SendOlaMapRequest("GET", apiURL, o.RequestId, oauthToken, &apiResponse)
+	err = o.send(ctx, EndpointSnapToRoad, "GET", apiURL, cred, &apiResponse)
 	if err != nil {
-		return nil, errors.New("failed to send request to Olamaps API")
+		return SnapToRoad{}, fmt.Errorf("failed to send request to Olamaps API: %w", err)
 	}
 
 	return apiResponse, nil
 }
 
 // GetNearestRoads
-func (o *OLAMap) GetNearestRoads(points string, radius string) (interface{}, error) {
-	if points == "" {
-		return nil, errors.New("Missing required query parameters: 'points' and/or 'radius'")
+func (o *OLAMap) GetNearestRoads(points Path, radius string) (NearestRoad, error) {
+	return o.GetNearestRoadsWithContext(context.Background(), points, radius)
+}
+
+// GetNearestRoadsWithContext is GetNearestRoads bound to ctx, which is carried to the outgoing request
+func (o *OLAMap) GetNearestRoadsWithContext(ctx context.Context, points Path, radius string) (NearestRoad, error) {
+	if len(points) == 0 {
+		return NearestRoad{}, validationError("Missing required query parameters: 'points' and/or 'radius'")
+	}
+	if err := validatePath("point", points); err != nil {
+		return NearestRoad{}, err
 	}
 
-	oauthToken := o.Token
-	if oauthToken == "" {
-		return nil, errors.New("Invalid OAuth token")
+	cred, err := o.credential(ctx)
+	if err != nil {
+		return NearestRoad{}, err
 	}
 
 	// Build the API URL
-	apiURL := fmt.Sprintf(NearestRoadsURL, points, radius)
+	apiURL := o.endpointURL(EndpointNearestRoads, url.QueryEscape(points.String()), radius)
 
 	var apiResponse NearestRoad
 
 	// Make the external request
-	err := o.HttpService.SendOlaMapRequest("GET", apiURL, o.RequestId, oauthToken, &apiResponse)
+	err = o.send(ctx, EndpointNearestRoads, "GET", apiURL, cred, &apiResponse)
 	if err != nil {
-		return nil, errors.New("failed to send request to Olamaps API")
+		return NearestRoad{}, fmt.Errorf("failed to send request to Olamaps API: %w", err)
 	}
 
 	return apiResponse, nil
 }
 
 // GetStaticMapImageCenter
-func (o *OLAMap) GetStaticMapImageCenter(mapImageCenter MapImageCenter) (interface{}, error) {
+func (o *OLAMap) GetStaticMapImageCenter(mapImageCenter MapImageCenter) (*StaticImage, error) {
+	return o.GetStaticMapImageCenterWithContext(context.Background(), mapImageCenter)
+}
+
+// GetStaticMapImageCenterWithContext is GetStaticMapImageCenter bound to ctx, which is carried to the outgoing request
+func (o *OLAMap) GetStaticMapImageCenterWithContext(ctx context.Context, mapImageCenter MapImageCenter) (*StaticImage, error) {
 	// Validate required parameters
 	if mapImageCenter.Stylename == "" || mapImageCenter.Longitude == "" || mapImageCenter.Latitude == "" || mapImageCenter.Zoomlevel == "" || mapImageCenter.Imagewidth == "" || mapImageCenter.Imageheight == "" || mapImageCenter.Imageformat == "" {
-		return nil, errors.New("Missing required query parameters: 'stylename' or 'longitude' or 'latitude' or 'zoomlevel' or 'width' or 'height' or 'format'")
+		return nil, validationError("Missing required query parameters: 'stylename' or 'longitude' or 'latitude' or 'zoomlevel' or 'width' or 'height' or 'format'")
 	}
 
 	longitude, err := strconv.ParseFloat(mapImageCenter.Longitude, 64)
 	if err != nil {
-		return nil, errors.New("Invalid longitude value")
+		return nil, validationError("Invalid longitude value")
 	}
 
 	latitude, err := strconv.ParseFloat(mapImageCenter.Latitude, 64)
 	if err != nil {
-		return nil, errors.New("Invalid latitude value")
+		return nil, validationError("Invalid latitude value")
 	}
 
 	zoomLevel, err := strconv.Atoi(mapImageCenter.Zoomlevel)
 	if err != nil {
-		return nil, errors.New("Invalid zoom level value")
+		return nil, validationError("Invalid zoom level value")
 	}
 
 	imageWidth, err := strconv.Atoi(mapImageCenter.Imagewidth)
 	if err != nil {
-		return nil, errors.New("Invalid image width value")
+		return nil, validationError("Invalid image width value")
 	}
 
 	imageHeight, err := strconv.Atoi(mapImageCenter.Imageheight)
 	if err != nil {
-		return nil, errors.New("Invalid image height value")
+		return nil, validationError("Invalid image height value")
 	}
 
-	oauthToken := o.Token
-	if oauthToken == "" {
-		return nil, errors.New("Invalid OAuth token")
+	cred, err := o.credential(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	// Construct the API URL
-	apiURL := fmt.Sprintf(StaticMapImageCenterURL,
+	apiURL := o.endpointURL(EndpointStaticMapImageCenter,
 		url.QueryEscape(mapImageCenter.Stylename), longitude, latitude, zoomLevel, imageWidth, imageHeight, mapImageCenter.Imageformat)
 
 	// Construct query parameters
@@ -387,74 +569,59 @@ func (o *OLAMap) GetStaticMapImageCenter(mapImageCenter MapImageCenter) (interfa
 		apiURL += "?" + queryParams.Encode()
 	}
 
-	// Create and send the external request
-	req, err := http.NewRequest("GET", apiURL, nil)
-	if err != nil {
-		return nil, errors.New("failed to send request to Olamaps API")
-	}
-
-	req.Header.Add("X-Request-Id", o.RequestId)
-
-	if oauthToken != "" {
-		req.Header.Add("Authorization", oauthToken)
-	}
-
-	xCorrelationID := uuid.New().String()
-	req.Header.Add("X-Correlation-Id", xCorrelationID)
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, errors.New("Failed to make external request")
-	}
-	defer resp.Body.Close()
-
-	return resp, nil
+	// Make the external request
+	image := &StaticImage{Width: imageWidth, Height: imageHeight, Format: mapImageCenter.Imageformat}
+	return o.getStaticImage(ctx, EndpointStaticMapImageCenter, apiURL, cred, image, mapImageCenter.Stream)
 }
 
 // GetStaticMapImageBounded
-func (o *OLAMap) GetStaticMapImageBounded(mapImageBounded MapImageBounded) (interface{}, error) {
+func (o *OLAMap) GetStaticMapImageBounded(mapImageBounded MapImageBounded) (*StaticImage, error) {
+	return o.GetStaticMapImageBoundedWithContext(context.Background(), mapImageBounded)
+}
+
+// GetStaticMapImageBoundedWithContext is GetStaticMapImageBounded bound to ctx, which is carried to the outgoing request
+func (o *OLAMap) GetStaticMapImageBoundedWithContext(ctx context.Context, mapImageBounded MapImageBounded) (*StaticImage, error) {
 	if mapImageBounded.Stylename == "" || mapImageBounded.Minxstr == "" || mapImageBounded.Minystr == "" || mapImageBounded.Maxxstr == "" || mapImageBounded.Maxystr == "" || mapImageBounded.Imagewidth == "" || mapImageBounded.Imageheight == "" || mapImageBounded.Imageformat == "" {
-		return nil, errors.New("Missing required query parameters: 'styleName' or 'minXStr' or 'minYStr' or 'maxXStr' or 'maxYStr' or 'imageWidthStr' or 'imageHeightStr' or 'imageFormat'")
+		return nil, validationError("Missing required query parameters: 'styleName' or 'minXStr' or 'minYStr' or 'maxXStr' or 'maxYStr' or 'imageWidthStr' or 'imageHeightStr' or 'imageFormat'")
 	}
 
 	minX, err := strconv.ParseFloat(mapImageBounded.Minxstr, 64)
 	if err != nil {
-		return nil, errors.New("Invalid min_x value")
+		return nil, validationError("Invalid min_x value")
 	}
 
 	minY, err := strconv.ParseFloat(mapImageBounded.Minystr, 64)
 	if err != nil {
-		return nil, errors.New("Invalid min_y value")
+		return nil, validationError("Invalid min_y value")
 	}
 
 	maxX, err := strconv.ParseFloat(mapImageBounded.Maxxstr, 64)
 	if err != nil {
-		return nil, errors.New("Invalid max_x value")
+		return nil, validationError("Invalid max_x value")
 	}
 
 	maxY, err := strconv.ParseFloat(mapImageBounded.Maxystr, 64)
 	if err != nil {
-		return nil, errors.New("Invalid max_y value")
+		return nil, validationError("Invalid max_y value")
 	}
 
 	imageWidth, err := strconv.Atoi(mapImageBounded.Imagewidth)
 	if err != nil {
-		return nil, errors.New("Invalid image width value")
+		return nil, validationError("Invalid image width value")
 	}
 
 	imageHeight, err := strconv.Atoi(mapImageBounded.Imageheight)
 	if err != nil {
-		return nil, errors.New("Invalid image height value")
+		return nil, validationError("Invalid image height value")
 	}
 
-	oauthToken := o.Token
-	if oauthToken == "" {
-		return nil, errors.New("Invalid OAuth token")
+	cred, err := o.credential(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	// Construct the API URL
-	apiURL := fmt.Sprintf(StaticMapImageBoundedURL,
+	apiURL := o.endpointURL(EndpointStaticMapImageBounded,
 		url.QueryEscape(mapImageBounded.Stylename), minX, minY, maxX, maxY, imageWidth, imageHeight, mapImageBounded.Imageformat)
 
 	// Construct query parameters
@@ -472,51 +639,38 @@ func (o *OLAMap) GetStaticMapImageBounded(mapImageBounded MapImageBounded) (inte
 	}
 
 	// Make the external request
-	req, err := http.NewRequest("GET", apiURL, nil)
-	if err != nil {
-		return nil, errors.New("failed to send request to Olamaps API")
-	}
-
-	req.Header.Add("X-Request-Id", o.RequestId)
-	req.Header.Add("X-Correlation-Id", o.RequestId)
-
-	if oauthToken != "" {
-		req.Header.Add("Authorization", oauthToken)
-	}
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, errors.New("Failed to make external request")
-	}
-	defer resp.Body.Close()
-
-	return resp, err
+	image := &StaticImage{Width: imageWidth, Height: imageHeight, Format: mapImageBounded.Imageformat}
+	return o.getStaticImage(ctx, EndpointStaticMapImageBounded, apiURL, cred, image, mapImageBounded.Stream)
 }
 
 // StaticMapImage
-func (o *OLAMap) StaticMapImage(mapImage MapImage) (interface{}, error) {
+func (o *OLAMap) StaticMapImage(mapImage MapImage) (*StaticImage, error) {
+	return o.StaticMapImageWithContext(context.Background(), mapImage)
+}
+
+// StaticMapImageWithContext is StaticMapImage bound to ctx, which is carried to the outgoing request
+func (o *OLAMap) StaticMapImageWithContext(ctx context.Context, mapImage MapImage) (*StaticImage, error) {
 	if mapImage.Stylename == "" || mapImage.Imagewidth == "" || mapImage.Imageheight == "" || mapImage.Imageformat == "" || mapImage.Path == "" {
-		return nil, errors.New("Missing required query parameters: 'stylename' or 'imagewidth' or 'imageheight' or 'imageformat' or path")
+		return nil, validationError("Missing required query parameters: 'stylename' or 'imagewidth' or 'imageheight' or 'imageformat' or path")
 	}
 
 	imageWidth, err := strconv.Atoi(mapImage.Imagewidth)
 	if err != nil {
-		return nil, errors.New("Invalid image width value")
+		return nil, validationError("Invalid image width value")
 	}
 
 	imageHeight, err := strconv.Atoi(mapImage.Imageheight)
 	if err != nil {
-		return nil, errors.New("Invalid image height value")
+		return nil, validationError("Invalid image height value")
 	}
 
-	oauthToken := o.Token
-	if oauthToken == "" {
-		return nil, errors.New("Invalid OAuth token")
+	cred, err := o.credential(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	// Construct the API URL
-	apiURL := fmt.Sprintf(StaticMapImageURL, url.QueryEscape(mapImage.Stylename), imageWidth, imageHeight, mapImage.Imageformat)
+	apiURL := o.endpointURL(EndpointStaticMapImage, url.QueryEscape(mapImage.Stylename), imageWidth, imageHeight, mapImage.Imageformat)
 
 	// Construct query parameters
 	queryParams := url.Values{}
@@ -531,23 +685,8 @@ func (o *OLAMap) StaticMapImage(mapImage MapImage) (interface{}, error) {
 	if len(queryParams) > 0 {
 		apiURL += "?" + queryParams.Encode()
 	}
-	// Create a new request
-	req, err := http.NewRequest("GET", apiURL, nil)
-	if err != nil {
-		return nil, errors.New("Failed to create request")
-	}
-
-	if oauthToken != "" {
-		req.Header.Add("Authorization", oauthToken)
-	}
 
-	// Make the request
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, errors.New("Failed to send request")
-	}
-	defer resp.Body.Close()
-
-	return resp, nil
+	// Make the external request
+	image := &StaticImage{Width: imageWidth, Height: imageHeight, Format: mapImage.Imageformat}
+	return o.getStaticImage(ctx, EndpointStaticMapImage, apiURL, cred, image, mapImage.Stream)
 }
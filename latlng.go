@@ -0,0 +1,95 @@
+package golamap
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// LatLng is a coordinate passed to the API. It is the same type as
+// Location, so coordinates from one response can be sent in the next
+// request.
+type LatLng = Location
+
+// Path is a sequence of coordinates, formatted as "lat,lng|lat,lng"
+type Path []LatLng
+
+// ParseLatLng parses a "lat,lng" string, rejecting coordinates out of range
+func ParseLatLng(s string) (LatLng, error) {
+	lat, lng, ok := strings.Cut(s, ",")
+	if !ok {
+		return LatLng{}, validationError(fmt.Sprintf("Invalid coordinates %q: expected \"lat,lng\"", s))
+	}
+
+	latitude, err := strconv.ParseFloat(strings.TrimSpace(lat), 64)
+	if err != nil {
+		return LatLng{}, validationError(fmt.Sprintf("Invalid coordinates %q: expected \"lat,lng\"", s))
+	}
+	longitude, err := strconv.ParseFloat(strings.TrimSpace(lng), 64)
+	if err != nil {
+		return LatLng{}, validationError(fmt.Sprintf("Invalid coordinates %q: expected \"lat,lng\"", s))
+	}
+
+	latLng := LatLng{Lat: latitude, Lng: longitude}
+	if err := validateLocation("latlng", latLng); err != nil {
+		return LatLng{}, err
+	}
+
+	return latLng, nil
+}
+
+// ParsePath parses a "lat,lng|lat,lng" string
+func ParsePath(s string) (Path, error) {
+	var path Path
+	for _, point := range strings.Split(s, "|") {
+		latLng, err := ParseLatLng(point)
+		if err != nil {
+			return nil, err
+		}
+		path = append(path, latLng)
+	}
+	return path, nil
+}
+
+// String formats l as "lat,lng", the form the API expects
+func (l Location) String() string {
+	return strconv.FormatFloat(l.Lat, 'f', -1, 64) + "," + strconv.FormatFloat(l.Lng, 'f', -1, 64)
+}
+
+// IsZero reports whether l is the zero value, which requests treat as unset
+func (l Location) IsZero() bool {
+	return l == Location{}
+}
+
+// String formats p as "lat,lng|lat,lng"
+func (p Path) String() string {
+	points := make([]string, len(p))
+	for i, point := range p {
+		points[i] = point.String()
+	}
+	return strings.Join(points, "|")
+}
+
+// String formats b as "lat,lng|lat,lng", southwest corner first, or "" for
+// the zero Bounds
+func (b Bounds) String() string {
+	if b.IsZero() {
+		return ""
+	}
+	return Path{b.Southwest, b.Northeast}.String()
+}
+
+// IsZero reports whether b is the zero value, which requests treat as unset
+func (b Bounds) IsZero() bool {
+	return b == Bounds{}
+}
+
+// validatePath checks every point of p is within range
+func validatePath(name string, p Path) error {
+	for i, point := range p {
+		if err := validateLocation(fmt.Sprintf("%s %d", name, i), point); err != nil {
+			return err
+		}
+	}
+	return nil
+}
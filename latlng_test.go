@@ -0,0 +1,73 @@
+package golamap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLatLng(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		latLng, err := ParseLatLng(" 12.931316, 77.616508 ")
+		assert.Nil(t, err)
+		assert.Equal(t, LatLng{Lat: 12.931316, Lng: 77.616508}, latLng)
+		assert.Equal(t, "12.931316,77.616508", latLng.String())
+	})
+	t.Run("malformed", func(t *testing.T) {
+		for _, s := range []string{"", "12.93", "12.93,", "north,77.61"} {
+			_, err := ParseLatLng(s)
+			assert.ErrorIs(t, err, ErrValidation, s)
+		}
+	})
+	t.Run("swapped", func(t *testing.T) {
+		_, err := ParseLatLng("77.616508,12.931316")
+		assert.Nil(t, err, "in range either way round")
+
+		_, err = ParseLatLng("120.5,12.9")
+		assert.ErrorIs(t, err, ErrValidation)
+		assert.EqualError(t, err, "Invalid latlng coordinates 120.5,12.9: latitude must be within ±90 and longitude within ±180 (latitude and longitude swapped?)")
+	})
+}
+
+func TestParsePath(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		path, err := ParsePath("12.93,77.61|12.97,77.59")
+		assert.Nil(t, err)
+		assert.Equal(t, Path{{Lat: 12.93, Lng: 77.61}, {Lat: 12.97, Lng: 77.59}}, path)
+		assert.Equal(t, "12.93,77.61|12.97,77.59", path.String())
+	})
+	t.Run("invalid point", func(t *testing.T) {
+		_, err := ParsePath("12.93,77.61|97.59,12.97")
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+}
+
+func TestCoordinateValidation(t *testing.T) {
+	swapped := LatLng{Lat: 120.5, Lng: 12.9}
+	olaMap := &OLAMap{Token: "mockToken", HttpService: &MockStruct{}}
+
+	_, err := olaMap.ReverseGeocode(swapped)
+	assert.EqualError(t, err, "Invalid latlng coordinates 120.5,12.9: latitude must be within ±90 and longitude within ±180 (latitude and longitude swapped?)")
+
+	_, err = olaMap.GetDirections(mockOrigin, swapped)
+	assert.ErrorIs(t, err, ErrValidation)
+
+	_, err = olaMap.GetDirectionsWithOptions(context.Background(), DirectionsRequest{Origin: mockOrigin, Destination: mockDestination, Waypoints: Path{swapped}})
+	assert.EqualError(t, err, "Invalid waypoint 0 coordinates 120.5,12.9: latitude must be within ±90 and longitude within ±180 (latitude and longitude swapped?)")
+
+	_, err = olaMap.GetDistanceMatrix(Path{mockOrigin}, Path{mockDestination, swapped})
+	assert.ErrorIs(t, err, ErrValidation)
+
+	_, err = olaMap.GetNearBySearch(NearBySearch{Layers: "venue", Location: swapped})
+	assert.ErrorIs(t, err, ErrValidation)
+
+	_, err = olaMap.GetTextSearch(TextSearch{Input: "cafe", Location: swapped})
+	assert.ErrorIs(t, err, ErrValidation)
+
+	_, err = olaMap.GetSnapToRoad(Path{mockOrigin, swapped}, "")
+	assert.ErrorIs(t, err, ErrValidation)
+
+	_, err = olaMap.GetNearestRoads(Path{swapped}, "")
+	assert.ErrorIs(t, err, ErrValidation)
+}
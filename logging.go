@@ -0,0 +1,152 @@
+package golamap
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// LogOptions chooses the levels and detail of the records written by
+// WithLogger
+type LogOptions struct {
+	RequestLevel  slog.Level // Record written as each request is sent
+	ResponseLevel slog.Level // Record written for each 2xx or 3xx response
+	ErrorLevel    slog.Level // Record written for failed requests and other responses
+	Headers       bool       // Include request headers, with Authorization redacted
+	Body          bool       // Include request bodies, with client_secret redacted
+}
+
+// DefaultLogOptions logs requests at Debug, responses at Info and failures
+// at Error, without headers or bodies
+func DefaultLogOptions() LogOptions {
+	return LogOptions{
+		RequestLevel:  slog.LevelDebug,
+		ResponseLevel: slog.LevelInfo,
+		ErrorLevel:    slog.LevelError,
+	}
+}
+
+// WithLogger logs every request the client sends, and its response, to
+// logger: the endpoint, method, URL, request ID, status and latency. The
+// api_key parameter, Authorization header and client_secret are redacted.
+// Records are written outside the built-in circuit breaker, retry and rate
+// limit handling, once per call. It does not apply to a custom HttpService.
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *OLAMap) {
+		o.logger = logger
+		if o.logOptions == nil {
+			options := DefaultLogOptions()
+			o.logOptions = &options
+		}
+	}
+}
+
+// WithLogOptions sets the levels and detail of the records written by
+// WithLogger, which otherwise uses DefaultLogOptions
+func WithLogOptions(options LogOptions) Option {
+	return func(o *OLAMap) {
+		o.logOptions = &options
+	}
+}
+
+func loggingMiddleware(logger *slog.Logger, options LogOptions) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return &loggingTransport{next: next, logger: logger, options: options}
+	}
+}
+
+// loggingTransport writes a record for each request and its outcome
+type loggingTransport struct {
+	next    http.RoundTripper
+	logger  *slog.Logger
+	options LogOptions
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := t.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	ctx := req.Context()
+	attrs := t.requestAttrs(req)
+	if t.logger.Enabled(ctx, t.options.RequestLevel) {
+		t.logger.LogAttrs(ctx, t.options.RequestLevel, "olamaps request", append(attrs, t.detailAttrs(req)...)...)
+	}
+
+	start := time.Now()
+	resp, err := next.RoundTrip(req)
+	attrs = append(attrs, slog.Duration("latency", time.Since(start)))
+
+	switch {
+	case err != nil:
+		attrs = append(attrs, slog.String("error", redactSecrets(err.Error(), req)))
+		t.logger.LogAttrs(ctx, t.options.ErrorLevel, "olamaps request failed", attrs...)
+	case resp.StatusCode >= 400:
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+		t.logger.LogAttrs(ctx, t.options.ErrorLevel, "olamaps request failed", attrs...)
+	default:
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+		t.logger.LogAttrs(ctx, t.options.ResponseLevel, "olamaps response", attrs...)
+	}
+
+	return resp, err
+}
+
+// requestAttrs identifies req in every record about it
+func (t *loggingTransport) requestAttrs(req *http.Request) []slog.Attr {
+	attrs := make([]slog.Attr, 0, 8)
+	if endpoint, ok := EndpointFromContext(req.Context()); ok {
+		attrs = append(attrs, slog.String("endpoint", string(endpoint)))
+	}
+	attrs = append(attrs,
+		slog.String("method", req.Method),
+		slog.String("url", RedactURL(req.URL)),
+	)
+	if requestID := req.Header.Get("X-Request-Id"); requestID != "" {
+		attrs = append(attrs, slog.String("request_id", requestID))
+	}
+	return attrs
+}
+
+// detailAttrs returns the headers and body of req, as chosen by the options
+func (t *loggingTransport) detailAttrs(req *http.Request) []slog.Attr {
+	var attrs []slog.Attr
+	if t.options.Headers {
+		redactedHeader := RedactHeader(req.Header)
+		names := make([]string, 0, len(redactedHeader))
+		for name := range redactedHeader {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		header := make([]any, 0, len(names))
+		for _, name := range names {
+			header = append(header, slog.String(name, strings.Join(redactedHeader[name], ", ")))
+		}
+		attrs = append(attrs, slog.Group("header", header...))
+	}
+	if t.options.Body && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, err := io.ReadAll(body)
+			body.Close()
+			if err == nil && len(data) > 0 {
+				attrs = append(attrs, slog.String("body", RedactForm(string(data))))
+			}
+		}
+	}
+	return attrs
+}
+
+// redactSecrets removes the api_key of req from msg, which may quote its URL
+func redactSecrets(msg string, req *http.Request) string {
+	if key := req.URL.Query().Get("api_key"); key != "" {
+		msg = strings.ReplaceAll(msg, url.QueryEscape(key), Redacted)
+		msg = strings.ReplaceAll(msg, key, Redacted)
+	}
+	return msg
+}
@@ -0,0 +1,120 @@
+package golamap
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// logRecords decodes the records written by a slog JSON handler
+func logRecords(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record map[string]interface{}
+		assert.Nil(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestWithLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/token"):
+			w.Write([]byte(`{"access_token":"mock-access-token","expires_in":3600}`))
+		case r.URL.Query().Get("address") == "fail":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.Write([]byte(GeoCodeResponse))
+		}
+	}))
+	defer server.Close()
+
+	debug := func(buf *bytes.Buffer) *slog.Logger {
+		return slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	}
+
+	t.Run("logs requests and responses", func(t *testing.T) {
+		var buf bytes.Buffer
+		olaMap := NewClient(WithBaseURL(APIPlaces, server.URL), WithAPIKey("secret-key"), WithRequestID("mock-request-id"), WithLogger(debug(&buf)))
+
+		_, err := olaMap.GeoCode("mumbai", Bounds{}, "")
+		assert.Nil(t, err)
+
+		records := logRecords(t, &buf)
+		assert.Len(t, records, 2)
+		assert.Equal(t, "olamaps request", records[0]["msg"])
+		assert.Equal(t, "DEBUG", records[0]["level"])
+		response := records[1]
+		assert.Equal(t, "olamaps response", response["msg"])
+		assert.Equal(t, "INFO", response["level"])
+		assert.Equal(t, "geocode", response["endpoint"])
+		assert.Equal(t, "GET", response["method"])
+		assert.Equal(t, "mock-request-id", response["request_id"])
+		assert.Equal(t, float64(http.StatusOK), response["status"])
+		assert.Contains(t, response, "latency")
+		assert.Contains(t, response["url"], "api_key=REDACTED")
+		assert.NotContains(t, buf.String(), "secret-key")
+	})
+	t.Run("logs failures at the error level", func(t *testing.T) {
+		var buf bytes.Buffer
+		olaMap := NewClient(WithBaseURL(APIPlaces, server.URL), WithAPIKey("secret-key"), WithLogger(debug(&buf)))
+
+		_, err := olaMap.GeoCode("fail", Bounds{}, "")
+		assert.NotNil(t, err)
+
+		records := logRecords(t, &buf)
+		assert.Len(t, records, 2)
+		assert.Equal(t, "olamaps request failed", records[1]["msg"])
+		assert.Equal(t, "ERROR", records[1]["level"])
+		assert.Equal(t, float64(http.StatusInternalServerError), records[1]["status"])
+	})
+	t.Run("redacts headers and client secrets", func(t *testing.T) {
+		var buf bytes.Buffer
+		options := DefaultLogOptions()
+		options.Headers = true
+		options.Body = true
+		olaMap := NewClient(WithBaseURL(APIAuth, server.URL), WithBaseURL(APIPlaces, server.URL),
+			WithClientCredentials("mock-client", "client-secret"), WithLogOptions(options), WithLogger(debug(&buf)))
+
+		_, err := olaMap.GeoCode("mumbai", Bounds{}, "")
+		assert.Nil(t, err)
+
+		records := logRecords(t, &buf)
+		assert.Len(t, records, 4)
+		assert.Equal(t, "token", records[0]["endpoint"])
+		assert.Contains(t, records[0]["body"], "client_id=mock-client")
+		assert.Contains(t, records[0]["body"], "client_secret=REDACTED")
+		assert.Equal(t, "geocode", records[2]["endpoint"])
+		assert.Equal(t, map[string]interface{}{"Authorization": "REDACTED", "X-Request-Id": records[2]["request_id"]}, records[2]["header"])
+		assert.NotContains(t, buf.String(), "client-secret")
+		assert.NotContains(t, buf.String(), "mock-access-token")
+	})
+	t.Run("configurable levels", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+		options := DefaultLogOptions()
+		options.ResponseLevel = slog.LevelDebug
+		olaMap := NewClient(WithBaseURL(APIPlaces, server.URL), WithAPIKey("secret-key"), WithLogger(logger), WithLogOptions(options))
+
+		_, err := olaMap.GeoCode("mumbai", Bounds{}, "")
+		assert.Nil(t, err)
+		assert.Empty(t, buf.String())
+	})
+}
+
+func TestRedactSecrets(t *testing.T) {
+	req, err := http.NewRequest("GET", "http://localhost/places/v1/geocode?api_key=a%2Fb&address=x", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, `Get "http://localhost/places/v1/geocode?api_key=REDACTED&address=x": refused`,
+		redactSecrets(`Get "http://localhost/places/v1/geocode?api_key=a%2Fb&address=x": refused`, req))
+}
@@ -0,0 +1,87 @@
+package golamap
+
+import "net/http"
+
+// Middleware wraps the transport used for every outbound request of a
+// client: JSON endpoints, static map images and token fetches alike. It can
+// log, stamp headers, inject auth, record metrics or assert in tests.
+//
+//	func stampTenant(next http.RoundTripper) http.RoundTripper {
+//		return golamap.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+//			req = req.Clone(req.Context())
+//			req.Header.Set("X-Tenant", "acme")
+//			return next.RoundTrip(req)
+//		})
+//	}
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function to an http.RoundTripper
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Chain combines middleware into one; the first runs outermost
+func Chain(middleware ...Middleware) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		if next == nil {
+			next = http.DefaultTransport
+		}
+		for i := len(middleware) - 1; i >= 0; i-- {
+			next = middleware[i](next)
+		}
+		return next
+	}
+}
+
+// WithMiddleware adds middleware around the client's transport. Middleware
+// runs in the order given, outside the built-in logging, user agent,
+// circuit breaker, retry and rate limit handling, so it sees each call once
+// however often it is retried. It does not apply to a custom HttpService.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(o *OLAMap) {
+		o.middleware = append(o.middleware, middleware...)
+	}
+}
+
+func userAgentMiddleware(userAgent string) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return &userAgentTransport{next: next, userAgent: userAgent}
+	}
+}
+
+func circuitBreakerMiddleware(breakers map[APIFamily]*circuitBreaker) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return &circuitBreakerTransport{next: next, breakers: breakers}
+	}
+}
+
+func retryMiddleware(policy RetryPolicy) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return &retryTransport{next: next, policy: policy}
+	}
+}
+
+func rateLimitMiddleware(limiters map[APIFamily]*rateLimiter) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return &rateLimitTransport{next: next, limiters: limiters}
+	}
+}
+
+// userAgentTransport sets the User-Agent header on outgoing requests
+type userAgentTransport struct {
+	next      http.RoundTripper
+	userAgent string
+}
+
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := t.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent)
+	return next.RoundTrip(req)
+}
@@ -0,0 +1,81 @@
+package golamap
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware(t *testing.T) {
+	var headers []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header.Get("X-Tenant"))
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/token"):
+			w.Write([]byte(`{"access_token":"mock-token","expires_in":3600}`))
+		case strings.Contains(r.URL.Path, "/static/"):
+			w.Write([]byte("mock-image"))
+		default:
+			w.Write([]byte(GeoCodeResponse))
+		}
+	}))
+	defer server.Close()
+
+	stamp := func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req = req.Clone(req.Context())
+			req.Header.Set("X-Tenant", "mock-tenant")
+			return next.RoundTrip(req)
+		})
+	}
+	var endpoints []Endpoint
+	record := func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			endpoint, _ := EndpointFromContext(req.Context())
+			endpoints = append(endpoints, endpoint)
+			return next.RoundTrip(req)
+		})
+	}
+
+	olaMap := NewClient(
+		WithBaseURL(APIAuth, server.URL),
+		WithBaseURL(APIPlaces, server.URL),
+		WithBaseURL(APITiles, server.URL),
+		WithClientCredentials("mock-client", "mock-secret"),
+		WithMiddleware(record, stamp),
+	)
+
+	_, err := olaMap.GeoCode("mock-address", Bounds{}, "")
+	assert.Nil(t, err)
+	_, err = olaMap.StaticMapImage(MapImage{Stylename: "mock-style", Imagewidth: "90", Imageheight: "100", Imageformat: "png", Path: "mock-path"})
+	assert.Nil(t, err)
+
+	assert.Equal(t, []Endpoint{EndpointToken, EndpointGeoCode, EndpointStaticMapImage}, endpoints)
+	assert.Equal(t, []string{"mock-tenant", "mock-tenant", "mock-tenant"}, headers)
+}
+
+func TestChain(t *testing.T) {
+	var order []string
+	named := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+	canned := RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		order = append(order, "transport")
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString("{}"))}, nil
+	})
+
+	req, _ := http.NewRequest("GET", "http://mock", nil)
+	_, err := Chain(named("first"), named("second"))(canned).RoundTrip(req)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"first", "second", "transport"}, order)
+}
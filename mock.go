@@ -1,6 +1,7 @@
 package golamap
 
 import (
+	"context"
 	"errors"
 	"strings"
 )
@@ -23,14 +24,14 @@ var (
 )
 
 type MockInterface interface {
-	SendOlaMapRequest(method, url, requestID, oauthToken string, responseObj interface{}) error
+	SendOlaMapRequest(ctx context.Context, method, url, requestID, oauthToken string, responseObj interface{}) error
 }
 type MockStruct struct {
 	MockBody   string
 	StatusCode int
 }
 
-func (mock *MockStruct) SendOlaMapRequest(method, url, requestID, oauthToken string, responseObj interface{}) error {
+func (mock *MockStruct) SendOlaMapRequest(ctx context.Context, method, url, requestID, oauthToken string, responseObj interface{}) error {
 	switch {
 	case strings.Contains(url, MockDirectionsURL):
 		mock.StatusCode = 200
@@ -73,7 +74,7 @@ func (mock *MockStruct) SendOlaMapRequest(method, url, requestID, oauthToken str
 		mock.MockBody = StyleDetailResponse
 	default:
 		mock.StatusCode = 400
-		mock.MockBody = nil
+		mock.MockBody = ""
 		return errors.New("Invalid request")
 	}
 	return nil
@@ -12,7 +12,16 @@ const (
 	  ],
 	  "routes": [
 		{
-		  "bounds": "string",
+		  "bounds": {
+			"southwest": {
+			  "lat": 12.90934,
+			  "lng": 77.61134
+			},
+			"northeast": {
+			  "lat": 12.93397,
+			  "lng": 77.62169
+			}
+		  },
 		  "copyrights": "OLA Map data ©2024",
 		  "legs": [
 			{
@@ -85,6 +94,7 @@ const (
 			]
 		  }
 		],
+		"status": "ok"
 	  }`
 
 	GeoCodeResponse = `{
@@ -205,9 +215,7 @@ const (
 			"address_components": [
 			  {
 				"types": [
-				  [
-					"country"
-				  ]
+				  "country"
 				],
 				"short_name": "India",
 				"long_name": "India"
@@ -302,7 +310,7 @@ const (
 		"info_messages": [],
 		"error_message": "",
 		"status": "ok"
-	  `
+	  }`
 
 	PlaceDetailResponse = `{
 		"html_attributions": [],
@@ -897,6 +905,12 @@ const (
 			  "pop": "Number",
 			  "popularity": "Number",
 			  "shortName": "String",
-			  "subclass": "Stri
-	  `
+			  "subclass": "String"
+			},
+			"id": "place",
+			"maxzoom": 14,
+			"minzoom": 2
+		  }
+		]
+	  }`
 )
@@ -0,0 +1,155 @@
+package golamap
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Option configures an OLAMap built by NewClient
+type Option func(*OLAMap)
+
+// NewClient builds an OLAMap from options. Clients are independent, so
+// several with different configurations can be used in one process.
+//
+//	olaMap := golamap.NewClient(
+//		golamap.WithAPIKey("your-api-key"),
+//		golamap.WithTimeout(10*time.Second),
+//	)
+func NewClient(opts ...Option) *OLAMap {
+	o := &OLAMap{
+		httpClient:         &http.Client{},
+		requestIDGenerator: uuid.NewString,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.httpClient == nil {
+		o.httpClient = &http.Client{}
+	}
+
+	client := *o.httpClient
+	if o.timeout > 0 {
+		client.Timeout = o.timeout
+	}
+	middleware := append([]Middleware{}, o.middleware...)
+	if o.logger != nil {
+		middleware = append(middleware, loggingMiddleware(o.logger, *o.logOptions))
+	}
+	if o.userAgent != "" {
+		middleware = append(middleware, userAgentMiddleware(o.userAgent))
+	}
+	if len(o.circuitBreakers) > 0 {
+		middleware = append(middleware, circuitBreakerMiddleware(o.circuitBreakers))
+	}
+	if o.retryPolicy != nil {
+		middleware = append(middleware, retryMiddleware(*o.retryPolicy))
+	}
+	if len(o.rateLimiters) > 0 {
+		middleware = append(middleware, rateLimitMiddleware(o.rateLimiters))
+	}
+	if len(middleware) > 0 {
+		client.Transport = Chain(middleware...)(client.Transport)
+	}
+	o.httpClient = &client
+
+	if o.HttpService == nil {
+		o.HttpService = &OlaRequest{Client: o.httpClient}
+	}
+	if source, ok := o.TokenSource.(*ClientCredentialsSource); ok {
+		if source.HTTPClient == nil {
+			source.HTTPClient = o.httpClient
+		}
+		if source.TokenURL == "" {
+			source.TokenURL = o.endpointURL(EndpointToken)
+		}
+	}
+
+	return o
+}
+
+// WithHTTPClient sets the http.Client used for every request, including
+// token fetches and static map images. A nil client uses the default.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *OLAMap) {
+		o.httpClient = client
+	}
+}
+
+// WithBaseURL points every endpoint of family at baseURL, e.g. a local
+// stand-in server. baseURL must not end with a slash.
+func WithBaseURL(family APIFamily, baseURL string) Option {
+	return func(o *OLAMap) {
+		if o.baseURLs == nil {
+			o.baseURLs = map[APIFamily]string{}
+		}
+		o.baseURLs[family] = baseURL
+	}
+}
+
+// WithUserAgent sets the User-Agent header of every request
+func WithUserAgent(userAgent string) Option {
+	return func(o *OLAMap) {
+		o.userAgent = userAgent
+	}
+}
+
+// WithTimeout limits the total time of each HTTP request
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *OLAMap) {
+		o.timeout = timeout
+	}
+}
+
+// WithRequestID sends the same X-Request-Id with every request
+func WithRequestID(requestID string) Option {
+	return func(o *OLAMap) {
+		o.RequestId = requestID
+	}
+}
+
+// WithRequestIDGenerator generates the X-Request-Id of each request when no
+// fixed request ID is set. NewClient defaults to random UUIDs.
+func WithRequestIDGenerator(generator func() string) Option {
+	return func(o *OLAMap) {
+		o.requestIDGenerator = generator
+	}
+}
+
+// WithHttpService replaces the HTTP service used for JSON endpoints
+func WithHttpService(service HttpServ) Option {
+	return func(o *OLAMap) {
+		o.HttpService = service
+	}
+}
+
+// WithTokenSource authenticates requests with source
+func WithTokenSource(source TokenSource) Option {
+	return func(o *OLAMap) {
+		o.TokenSource = source
+	}
+}
+
+// WithClientCredentials authenticates requests with OAuth tokens fetched,
+// and refreshed, using the client_credentials grant
+func WithClientCredentials(clientID, clientSecret string) Option {
+	return func(o *OLAMap) {
+		o.TokenSource = NewClientCredentialsSource(clientID, clientSecret)
+	}
+}
+
+// WithToken authenticates requests with a fixed Authorization header value,
+// e.g. "Bearer ..."
+func WithToken(token string) Option {
+	return func(o *OLAMap) {
+		o.Token = token
+	}
+}
+
+// WithAPIKey authenticates requests with an api_key query parameter
+func WithAPIKey(apiKey string) Option {
+	return func(o *OLAMap) {
+		o.APIKey = apiKey
+	}
+}
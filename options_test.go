@@ -0,0 +1,84 @@
+package golamap
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClient(t *testing.T) {
+	var mu sync.Mutex
+	var last *http.Request
+	lastRequest := func() *http.Request {
+		mu.Lock()
+		defer mu.Unlock()
+		return last
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		last = r
+		mu.Unlock()
+		switch r.URL.Path {
+		case "/places/v1/geocode":
+			w.Write([]byte(GeoCodeResponse))
+		case "/slow/places/v1/geocode":
+			select {
+			case <-time.After(100 * time.Millisecond):
+			case <-r.Context().Done():
+			}
+		default:
+			w.Header().Set("Content-Type", "image/png")
+			w.Write([]byte("mock-image"))
+		}
+	}))
+	defer server.Close()
+
+	t.Run("base url, user agent and request id", func(t *testing.T) {
+		olaMap := NewClient(
+			WithBaseURL(APIPlaces, server.URL),
+			WithAPIKey("mock-key"),
+			WithUserAgent("mock-agent"),
+			WithRequestIDGenerator(func() string { return "mock-request-id" }),
+		)
+		geocode, err := olaMap.GeoCode("mock-address", Bounds{}, "")
+		assert.Nil(t, err)
+		assert.Equal(t, "ok", geocode.Status)
+		assert.Equal(t, "mock-agent", lastRequest().UserAgent())
+		assert.Equal(t, "mock-request-id", lastRequest().Header.Get("X-Request-Id"))
+		assert.Equal(t, "mock-key", lastRequest().URL.Query().Get("api_key"))
+	})
+	t.Run("fixed request id", func(t *testing.T) {
+		olaMap := NewClient(WithBaseURL(APIPlaces, server.URL), WithAPIKey("mock-key"), WithRequestID("fixed-id"))
+		olaMap.GeoCode("mock-address", Bounds{}, "")
+		assert.Equal(t, "fixed-id", lastRequest().Header.Get("X-Request-Id"))
+	})
+	t.Run("static image", func(t *testing.T) {
+		olaMap := NewClient(WithBaseURL(APITiles, server.URL), WithToken("mockToken"))
+		image, err := olaMap.StaticMapImage(MapImage{Stylename: "mock-style", Imagewidth: "90", Imageheight: "100", Imageformat: "png", Path: "mock-path"})
+		assert.Nil(t, err)
+		assert.Equal(t, []byte("mock-image"), image.Data)
+		assert.Equal(t, "/tiles/v1/styles/mock-style/static/auto/90x100.png", lastRequest().URL.Path)
+		assert.Equal(t, "mockToken", lastRequest().Header.Get("Authorization"))
+	})
+	t.Run("timeout", func(t *testing.T) {
+		olaMap := NewClient(WithBaseURL(APIPlaces, server.URL+"/slow"), WithAPIKey("mock-key"), WithTimeout(10*time.Millisecond))
+		_, err := olaMap.GeoCode("mock-address", Bounds{}, "")
+		assert.NotNil(t, err)
+	})
+	t.Run("nil http client", func(t *testing.T) {
+		olaMap := NewClient(WithHTTPClient(nil), WithBaseURL(APIPlaces, server.URL), WithAPIKey("mock-key"))
+		_, err := olaMap.GeoCode("mock-address", Bounds{}, "")
+		assert.Nil(t, err)
+	})
+	t.Run("independent clients", func(t *testing.T) {
+		local := NewClient(WithBaseURL(APIPlaces, server.URL))
+		remote := NewClient()
+		assert.Equal(t, server.URL+"/places/v1/details?place_id=mock-id", local.endpointURL(EndpointPlaceDetail, "mock-id"))
+		assert.Equal(t, "https://api.olamaps.io/places/v1/details?place_id=mock-id", remote.endpointURL(EndpointPlaceDetail, "mock-id"))
+		assert.Equal(t, "https://account.olamaps.io/realms/olamaps/protocol/openid-connect/token", remote.endpointURL(EndpointToken))
+	})
+}
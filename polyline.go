@@ -0,0 +1,27 @@
+package golamap
+
+import "github.com/golang-mitrah/golamap/polyline"
+
+// Geometry decodes OverviewPolyline
+func (r Route) Geometry() ([]Location, error) {
+	return decodePolyline(r.OverviewPolyline)
+}
+
+// Geometry decodes Polyline
+func (e Element) Geometry() ([]Location, error) {
+	return decodePolyline(e.Polyline)
+}
+
+func decodePolyline(encoded string) ([]Location, error) {
+	points, err := polyline.Decode(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	locations := make([]Location, len(points))
+	for i, p := range points {
+		locations[i] = Location(p)
+	}
+
+	return locations, nil
+}
@@ -0,0 +1,140 @@
+// Package polyline encodes and decodes the encoded polyline format used by
+// Route.OverviewPolyline and Element.Polyline.
+//
+// See https://developers.google.com/maps/documentation/utilities/polylinealgorithm
+package polyline
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// DefaultPrecision is the number of decimal places Ola Maps encodes
+const DefaultPrecision = 5
+
+// ErrInvalidPrecision is returned for a precision other than 5 or 6
+var ErrInvalidPrecision = errors.New("polyline: precision must be 5 or 6")
+
+// Point is a decoded coordinate. It converts directly to golamap.Location:
+//
+//	location := golamap.Location(point)
+type Point struct {
+	Lat float64
+	Lng float64
+}
+
+// Decode decodes a polyline encoded with DefaultPrecision
+func Decode(encoded string) ([]Point, error) {
+	return DecodeWithPrecision(encoded, DefaultPrecision)
+}
+
+// DecodeWithPrecision decodes a polyline encoded with precision decimal
+// places, which must be 5 or 6
+func DecodeWithPrecision(encoded string, precision int) ([]Point, error) {
+	factor, err := precisionFactor(precision)
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]Point, 0, len(encoded)/4)
+	var lat, lng int64
+	for i := 0; i < len(encoded); {
+		dLat, next, err := decodeValue(encoded, i)
+		if err != nil {
+			return nil, err
+		}
+		if next == len(encoded) {
+			return nil, fmt.Errorf("polyline: missing longitude at offset %d", next)
+		}
+		dLng, next, err := decodeValue(encoded, next)
+		if err != nil {
+			return nil, err
+		}
+		i = next
+
+		lat += dLat
+		lng += dLng
+		points = append(points, Point{Lat: float64(lat) / factor, Lng: float64(lng) / factor})
+	}
+
+	return points, nil
+}
+
+// Encode encodes points with DefaultPrecision
+func Encode(points []Point) string {
+	encoded, _ := EncodeWithPrecision(points, DefaultPrecision)
+	return encoded
+}
+
+// EncodeWithPrecision encodes points with precision decimal places, which
+// must be 5 or 6
+func EncodeWithPrecision(points []Point, precision int) (string, error) {
+	factor, err := precisionFactor(precision)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	var prevLat, prevLng int64
+	for _, p := range points {
+		lat := int64(math.Round(p.Lat * factor))
+		lng := int64(math.Round(p.Lng * factor))
+		encodeValue(&b, lat-prevLat)
+		encodeValue(&b, lng-prevLng)
+		prevLat, prevLng = lat, lng
+	}
+
+	return b.String(), nil
+}
+
+func precisionFactor(precision int) (float64, error) {
+	if precision != 5 && precision != 6 {
+		return 0, ErrInvalidPrecision
+	}
+	return math.Pow10(precision), nil
+}
+
+// decodeValue reads one signed value starting at offset i and returns it
+// with the offset of the next value
+func decodeValue(encoded string, i int) (int64, int, error) {
+	var result uint64
+	var shift uint
+	for {
+		if i >= len(encoded) {
+			return 0, i, fmt.Errorf("polyline: unterminated value at offset %d", i)
+		}
+		c := int64(encoded[i]) - 63
+		if c < 0 || c > 95 {
+			return 0, i, fmt.Errorf("polyline: invalid character %q at offset %d", encoded[i], i)
+		}
+		if shift > 55 {
+			return 0, i, fmt.Errorf("polyline: value too long at offset %d", i)
+		}
+		i++
+
+		result |= uint64(c&0x1f) << shift
+		shift += 5
+		if c < 0x20 {
+			break
+		}
+	}
+
+	if result&1 != 0 {
+		return ^int64(result >> 1), i, nil
+	}
+	return int64(result >> 1), i, nil
+}
+
+func encodeValue(b *strings.Builder, v int64) {
+	u := uint64(v) << 1
+	if v < 0 {
+		u = ^u
+	}
+	for u >= 0x20 {
+		b.WriteByte(byte((0x20 | (u & 0x1f)) + 63))
+		u >>= 5
+	}
+	b.WriteByte(byte(u + 63))
+}
@@ -0,0 +1,57 @@
+package polyline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecode(t *testing.T) {
+	t.Run("reference polyline", func(t *testing.T) {
+		points, err := Decode("_p~iF~ps|U_ulLnnqC_mqNvxq`@")
+		assert.Nil(t, err)
+		assert.Equal(t, []Point{{38.5, -120.2}, {40.7, -120.95}, {43.252, -126.453}}, points)
+	})
+	t.Run("precision 6", func(t *testing.T) {
+		encoded, err := EncodeWithPrecision([]Point{{12.931316, 77.616508}}, 6)
+		assert.Nil(t, err)
+		points, err := DecodeWithPrecision(encoded, 6)
+		assert.Nil(t, err)
+		assert.InDelta(t, 12.931316, points[0].Lat, 1e-9)
+		assert.InDelta(t, 77.616508, points[0].Lng, 1e-9)
+	})
+	t.Run("empty", func(t *testing.T) {
+		points, err := Decode("")
+		assert.Nil(t, err)
+		assert.Empty(t, points)
+	})
+	t.Run("malformed", func(t *testing.T) {
+		_, err := Decode("_p~iF~ps|")
+		assert.EqualError(t, err, "polyline: unterminated value at offset 9")
+		_, err = Decode("_p~iF")
+		assert.EqualError(t, err, "polyline: missing longitude at offset 5")
+		_, err = Decode("_p~iF ")
+		assert.EqualError(t, err, "polyline: invalid character ' ' at offset 5")
+	})
+	t.Run("invalid precision", func(t *testing.T) {
+		_, err := DecodeWithPrecision("_p~iF~ps|U", 7)
+		assert.ErrorIs(t, err, ErrInvalidPrecision)
+	})
+}
+
+func TestEncode(t *testing.T) {
+	t.Run("reference polyline", func(t *testing.T) {
+		encoded := Encode([]Point{{38.5, -120.2}, {40.7, -120.95}, {43.252, -126.453}})
+		assert.Equal(t, "_p~iF~ps|U_ulLnnqC_mqNvxq`@", encoded)
+	})
+	t.Run("round trip", func(t *testing.T) {
+		points := []Point{{12.90934, 77.62169}, {12.90871, 77.62158}, {-33.86785, 151.20732}}
+		decoded, err := Decode(Encode(points))
+		assert.Nil(t, err)
+		assert.Equal(t, points, decoded)
+	})
+	t.Run("invalid precision", func(t *testing.T) {
+		_, err := EncodeWithPrecision(nil, 4)
+		assert.ErrorIs(t, err, ErrInvalidPrecision)
+	})
+}
@@ -0,0 +1,26 @@
+package golamap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeometry(t *testing.T) {
+	t.Run("route", func(t *testing.T) {
+		route := Route{OverviewPolyline: "_p~iF~ps|U_ulLnnqC_mqNvxq`@"}
+		geometry, err := route.Geometry()
+		assert.Nil(t, err)
+		assert.Equal(t, []Location{{38.5, -120.2}, {40.7, -120.95}, {43.252, -126.453}}, geometry)
+	})
+	t.Run("element", func(t *testing.T) {
+		element := Element{Polyline: "_p~iF~ps|U"}
+		geometry, err := element.Geometry()
+		assert.Nil(t, err)
+		assert.Equal(t, []Location{{38.5, -120.2}}, geometry)
+	})
+	t.Run("malformed", func(t *testing.T) {
+		_, err := Element{Polyline: "_p~iF"}.Geometry()
+		assert.Error(t, err)
+	})
+}
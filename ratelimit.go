@@ -0,0 +1,156 @@
+package golamap
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RateLimit caps the request rate of one APIFamily. Zero fields mean no
+// limit. Requests over the limit wait for capacity, unless FailFast is set
+// or the wait would outlast the request context's deadline, in which case
+// they fail with an error matching ErrRateLimited.
+type RateLimit struct {
+	PerSecond int  // Sustained requests per second
+	PerMinute int  // Sustained requests per minute
+	Burst     int  // Requests allowed at once; defaults to PerSecond
+	FailFast  bool // Fail instead of waiting for capacity
+}
+
+// WithRateLimit limits requests to every endpoint of family. Limits apply
+// per client, to each attempt that reaches the network.
+func WithRateLimit(family APIFamily, limit RateLimit) Option {
+	return func(o *OLAMap) {
+		if o.rateLimiters == nil {
+			o.rateLimiters = map[APIFamily]*rateLimiter{}
+		}
+		o.rateLimiters[family] = newRateLimiter(limit, time.Now)
+	}
+}
+
+// rateLimiter enforces a RateLimit with one token bucket per window
+type rateLimiter struct {
+	failFast bool
+	buckets  []*tokenBucket
+}
+
+func newRateLimiter(limit RateLimit, now func() time.Time) *rateLimiter {
+	limiter := &rateLimiter{failFast: limit.FailFast}
+	if limit.PerSecond > 0 {
+		burst := limit.Burst
+		if burst <= 0 {
+			burst = limit.PerSecond
+		}
+		limiter.buckets = append(limiter.buckets, newTokenBucket(float64(limit.PerSecond), float64(burst), now))
+	}
+	if limit.PerMinute > 0 {
+		limiter.buckets = append(limiter.buckets, newTokenBucket(float64(limit.PerMinute)/60, float64(limit.PerMinute), now))
+	}
+	return limiter
+}
+
+// Wait blocks until a request may be sent
+func (l *rateLimiter) Wait(ctx context.Context, family APIFamily) error {
+	var wait time.Duration
+	for _, bucket := range l.buckets {
+		if d := bucket.reserve(); d > wait {
+			wait = d
+		}
+	}
+	if wait == 0 {
+		return nil
+	}
+
+	deadline, hasDeadline := ctx.Deadline()
+	if l.failFast || (hasDeadline && time.Until(deadline) < wait) {
+		l.cancel()
+		return &kindError{msg: fmt.Sprintf("Olamaps %s rate limit exceeded", family), kind: ErrRateLimited}
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		l.cancel()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// cancel returns a reservation that was not used
+func (l *rateLimiter) cancel() {
+	for _, bucket := range l.buckets {
+		bucket.release()
+	}
+}
+
+// tokenBucket refills at rate tokens per second up to burst. Reservations
+// may drive it negative, which is how waiting callers queue up.
+type tokenBucket struct {
+	rate  float64
+	burst float64
+	now   func() time.Time
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate, burst float64, now func() time.Time) *tokenBucket {
+	return &tokenBucket{rate: rate, burst: burst, now: now, tokens: burst, last: now()}
+}
+
+// reserve takes a token and returns how long until it is available
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+func (b *tokenBucket) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens++
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+}
+
+// rateLimitTransport waits on the limiter of each request's APIFamily
+type rateLimitTransport struct {
+	next     http.RoundTripper
+	limiters map[APIFamily]*rateLimiter
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := t.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	if endpoint, ok := EndpointFromContext(req.Context()); ok {
+		if limiter, ok := t.limiters[endpoint.Family()]; ok {
+			if err := limiter.Wait(req.Context(), endpoint.Family()); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return next.RoundTrip(req)
+}
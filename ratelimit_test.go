@@ -0,0 +1,91 @@
+package golamap
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Now()
+	bucket := newTokenBucket(2, 2, func() time.Time { return now })
+
+	assert.Equal(t, time.Duration(0), bucket.reserve())
+	assert.Equal(t, time.Duration(0), bucket.reserve())
+	assert.Equal(t, 500*time.Millisecond, bucket.reserve())
+	assert.Equal(t, time.Second, bucket.reserve())
+
+	bucket.release()
+	bucket.release()
+	now = now.Add(time.Second)
+	assert.Equal(t, time.Duration(0), bucket.reserve())
+}
+
+func TestRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(GeoCodeResponse))
+	}))
+	defer server.Close()
+
+	t.Run("fail fast", func(t *testing.T) {
+		olaMap := NewClient(WithBaseURL(APIPlaces, server.URL), WithAPIKey("mock-key"),
+			WithRateLimit(APIPlaces, RateLimit{PerSecond: 1, PerMinute: 60, FailFast: true}))
+
+		_, err := olaMap.GeoCode("mock-address", Bounds{}, "")
+		assert.Nil(t, err)
+		_, err = olaMap.GeoCode("mock-address", Bounds{}, "")
+		assert.ErrorIs(t, err, ErrRateLimited)
+	})
+	t.Run("block until capacity", func(t *testing.T) {
+		olaMap := NewClient(WithBaseURL(APIPlaces, server.URL), WithAPIKey("mock-key"),
+			WithRateLimit(APIPlaces, RateLimit{PerSecond: 20, Burst: 1}))
+
+		start := time.Now()
+		for i := 0; i < 3; i++ {
+			_, err := olaMap.GeoCode("mock-address", Bounds{}, "")
+			assert.Nil(t, err)
+		}
+		assert.True(t, time.Since(start) >= 90*time.Millisecond)
+	})
+	t.Run("deadline shorter than wait", func(t *testing.T) {
+		olaMap := NewClient(WithBaseURL(APIPlaces, server.URL), WithAPIKey("mock-key"),
+			WithRateLimit(APIPlaces, RateLimit{PerMinute: 1}))
+		olaMap.GeoCode("mock-address", Bounds{}, "")
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		start := time.Now()
+		_, err := olaMap.GeoCodeWithContext(ctx, "mock-address", Bounds{}, "")
+		assert.ErrorIs(t, err, ErrRateLimited)
+		assert.True(t, time.Since(start) < 500*time.Millisecond)
+	})
+	t.Run("fail fast is not retried", func(t *testing.T) {
+		var attempts int
+		policy := DefaultRetryPolicy()
+		policy.OnAttempt = func(RetryAttempt) { attempts++ }
+		olaMap := NewClient(WithBaseURL(APIPlaces, server.URL), WithAPIKey("mock-key"), WithRetryPolicy(policy),
+			WithRateLimit(APIPlaces, RateLimit{PerSecond: 1, FailFast: true}))
+
+		_, err := olaMap.GeoCode("mock-address", Bounds{}, "")
+		assert.Nil(t, err)
+		attempts = 0
+		start := time.Now()
+		_, err = olaMap.GeoCode("mock-address", Bounds{}, "")
+		assert.ErrorIs(t, err, ErrRateLimited)
+		assert.Equal(t, 1, attempts)
+		assert.Less(t, time.Since(start), 100*time.Millisecond)
+	})
+	t.Run("families are independent", func(t *testing.T) {
+		olaMap := NewClient(WithBaseURL(APIPlaces, server.URL), WithAPIKey("mock-key"),
+			WithRateLimit(APIRouting, RateLimit{PerSecond: 1, FailFast: true}))
+
+		for i := 0; i < 3; i++ {
+			_, err := olaMap.GeoCode("mock-address", Bounds{}, "")
+			assert.Nil(t, err)
+		}
+	})
+}
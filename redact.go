@@ -0,0 +1,54 @@
+package golamap
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+// Redacted replaces secrets in logs and recorded requests
+const Redacted = "REDACTED"
+
+// RedactURL formats u with its api_key parameter redacted
+func RedactURL(u *url.URL) string {
+	query := u.Query()
+	if !query.Has("api_key") {
+		return u.String()
+	}
+	query.Set("api_key", Redacted)
+	redacted := *u
+	redacted.RawQuery = query.Encode()
+	return redacted.String()
+}
+
+// RedactHeader returns a copy of header with its Authorization redacted
+func RedactHeader(header http.Header) http.Header {
+	redacted := header.Clone()
+	if redacted.Get("Authorization") != "" {
+		redacted.Set("Authorization", Redacted)
+	}
+	return redacted
+}
+
+// RedactForm redacts the client_secret of a form-encoded body, such as a
+// token request
+func RedactForm(body string) string {
+	form, err := url.ParseQuery(body)
+	if err != nil || !form.Has("client_secret") {
+		return body
+	}
+	form.Set("client_secret", Redacted)
+	return form.Encode()
+}
+
+// redactError redacts the api_key of the URL quoted by a *url.Error in err,
+// so callers can log errors returned by the client
+func redactError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		if u, parseErr := url.Parse(urlErr.URL); parseErr == nil {
+			urlErr.URL = RedactURL(u)
+		}
+	}
+	return err
+}
@@ -0,0 +1,30 @@
+package golamap
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedact(t *testing.T) {
+	t.Run("url", func(t *testing.T) {
+		u, _ := url.Parse("http://localhost/places/v1/geocode?api_key=a%2Fb&address=x")
+		assert.Equal(t, "http://localhost/places/v1/geocode?address=x&api_key=REDACTED", RedactURL(u))
+
+		u, _ = url.Parse("http://localhost/places/v1/geocode?language=&address=x")
+		assert.Equal(t, "http://localhost/places/v1/geocode?language=&address=x", RedactURL(u), "left as is without an api_key")
+	})
+	t.Run("header", func(t *testing.T) {
+		header := http.Header{"Authorization": {"Bearer secret"}, "X-Request-Id": {"mock-id"}}
+		redacted := RedactHeader(header)
+		assert.Equal(t, http.Header{"Authorization": {Redacted}, "X-Request-Id": {"mock-id"}}, redacted)
+		assert.Equal(t, "Bearer secret", header.Get("Authorization"), "the original is unchanged")
+	})
+	t.Run("form", func(t *testing.T) {
+		assert.Equal(t, "client_id=mock-client&client_secret=REDACTED&grant_type=client_credentials",
+			RedactForm("grant_type=client_credentials&client_id=mock-client&client_secret=secret"))
+		assert.Equal(t, "grant_type=client_credentials", RedactForm("grant_type=client_credentials"))
+	})
+}
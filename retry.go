@@ -0,0 +1,204 @@
+package golamap
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy retries transient failures with exponential backoff and
+// jitter. It is applied by the client's transport to idempotent requests:
+// GET, HEAD and OPTIONS, plus the directions and token endpoints whose POSTs
+// are safe to repeat. A Retry-After header replaces the computed delay; when
+// it asks for more than MaxRetryAfter, or the wait would outlast the
+// request's deadline, the last response is returned instead.
+type RetryPolicy struct {
+	MaxAttempts          int           // Total attempts including the first; 1 or less disables retries
+	InitialBackoff       time.Duration // Delay before the first retry
+	MaxBackoff           time.Duration // Upper bound for the computed delay
+	MaxRetryAfter        time.Duration // Longest Retry-After to wait for; defaults to a minute
+	Multiplier           float64       // Backoff growth per attempt
+	Jitter               float64       // Fraction (0-1) of each delay that is randomized
+	RetryableStatusCodes []int         // Status codes worth retrying
+	OnAttempt            func(RetryAttempt)
+}
+
+// RetryAttempt describes one attempt, as passed to RetryPolicy.OnAttempt
+type RetryAttempt struct {
+	Request  *http.Request
+	Attempt  int            // 1 for the first attempt
+	Response *http.Response // nil when the attempt failed without a response
+	Err      error
+	Wait     time.Duration // Delay before the next attempt; zero when none follows
+}
+
+// DefaultRetryPolicy retries up to three times on connection errors, 429
+// and 5xx gateway errors, starting at 200ms
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:          4,
+		InitialBackoff:       200 * time.Millisecond,
+		MaxBackoff:           5 * time.Second,
+		MaxRetryAfter:        time.Minute,
+		Multiplier:           2,
+		Jitter:               0.2,
+		RetryableStatusCodes: []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+	}
+}
+
+// WithRetryPolicy retries transient failures of idempotent requests and
+// token fetches according to policy
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *OLAMap) {
+		o.retryPolicy = &policy
+	}
+}
+
+// retryTransport applies a RetryPolicy around another RoundTripper
+type retryTransport struct {
+	next   http.RoundTripper
+	policy RetryPolicy
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := t.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	if !t.retryable(req) {
+		return next.RoundTrip(req)
+	}
+
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		resp, err := next.RoundTrip(req)
+
+		var wait time.Duration
+		retry := attempt < t.policy.MaxAttempts && req.Context().Err() == nil && t.shouldRetry(resp, err)
+		if retry {
+			wait, retry = t.backoff(attempt, resp)
+		}
+		if retry {
+			deadline, hasDeadline := req.Context().Deadline()
+			retry = !hasDeadline || time.Until(deadline) >= wait
+		}
+		if !retry {
+			wait = 0
+		}
+
+		if t.policy.OnAttempt != nil {
+			t.policy.OnAttempt(RetryAttempt{Request: req, Attempt: attempt, Response: resp, Err: err, Wait: wait})
+		}
+
+		if !retry {
+			return resp, err
+		}
+
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// retryable reports whether req may be sent more than once
+func (t *retryTransport) retryable(req *http.Request) bool {
+	if t.policy.MaxAttempts <= 1 {
+		return false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+
+	endpoint, _ := EndpointFromContext(req.Context())
+	return endpoint == EndpointDirections || endpoint == EndpointToken
+}
+
+func (t *retryTransport) shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		// The client's own rate limit has already decided not to wait
+		return !errors.Is(err, ErrRateLimited)
+	}
+	for _, code := range t.policy.RetryableStatusCodes {
+		if resp.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the delay before the attempt following attempt, honoring
+// a Retry-After header when the response carries one. It reports false when
+// Retry-After asks for longer than MaxRetryAfter.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) (time.Duration, bool) {
+	if resp != nil {
+		if wait, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			maxWait := t.policy.MaxRetryAfter
+			if maxWait <= 0 {
+				maxWait = time.Minute
+			}
+			return wait, wait <= maxWait
+		}
+	}
+
+	multiplier := t.policy.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	wait := float64(t.policy.InitialBackoff)
+	for i := 1; i < attempt; i++ {
+		wait *= multiplier
+	}
+	if t.policy.MaxBackoff > 0 && wait > float64(t.policy.MaxBackoff) {
+		wait = float64(t.policy.MaxBackoff)
+	}
+	if t.policy.Jitter > 0 {
+		wait -= wait * t.policy.Jitter * rand.Float64()
+	}
+
+	return time.Duration(wait), true
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
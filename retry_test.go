@@ -0,0 +1,152 @@
+package golamap
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newFlakyServer fails the first failures requests with status, then
+// answers with body
+func newFlakyServer(t *testing.T, failures int32, status int, header http.Header, body string) (*httptest.Server, *int32) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= failures {
+			for key, values := range header {
+				w.Header()[key] = values
+			}
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func fastRetryPolicy() RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	policy.MaxBackoff = 5 * time.Millisecond
+	return policy
+}
+
+func TestRetryPolicy(t *testing.T) {
+	t.Run("retry transient status", func(t *testing.T) {
+		server, calls := newFlakyServer(t, 2, http.StatusBadGateway, nil, GeoCodeResponse)
+		var attempts []RetryAttempt
+		policy := fastRetryPolicy()
+		policy.OnAttempt = func(attempt RetryAttempt) { attempts = append(attempts, attempt) }
+		olaMap := NewClient(WithBaseURL(APIPlaces, server.URL), WithAPIKey("mock-key"), WithRetryPolicy(policy))
+
+		geocode, err := olaMap.GeoCode("mock-address", Bounds{}, "")
+		assert.Nil(t, err)
+		assert.Equal(t, "ok", geocode.Status)
+		assert.Equal(t, int32(3), *calls)
+		assert.Equal(t, 3, len(attempts))
+		assert.Equal(t, http.StatusBadGateway, attempts[0].Response.StatusCode)
+		assert.True(t, attempts[0].Wait > 0)
+		assert.Equal(t, time.Duration(0), attempts[2].Wait)
+	})
+	t.Run("give up after max attempts", func(t *testing.T) {
+		server, calls := newFlakyServer(t, 10, http.StatusServiceUnavailable, nil, "")
+		olaMap := NewClient(WithBaseURL(APIPlaces, server.URL), WithAPIKey("mock-key"), WithRetryPolicy(fastRetryPolicy()))
+
+		_, err := olaMap.GeoCode("mock-address", Bounds{}, "")
+		var apiErr *APIError
+		assert.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+		assert.Equal(t, int32(4), *calls)
+	})
+	t.Run("non retryable status", func(t *testing.T) {
+		server, calls := newFlakyServer(t, 1, http.StatusBadRequest, nil, GeoCodeResponse)
+		olaMap := NewClient(WithBaseURL(APIPlaces, server.URL), WithAPIKey("mock-key"), WithRetryPolicy(fastRetryPolicy()))
+
+		_, err := olaMap.GeoCode("mock-address", Bounds{}, "")
+		assert.NotNil(t, err)
+		assert.Equal(t, int32(1), *calls)
+	})
+	t.Run("honor retry after", func(t *testing.T) {
+		server, _ := newFlakyServer(t, 1, http.StatusTooManyRequests, http.Header{"Retry-After": {"1"}}, GeoCodeResponse)
+		var waits []time.Duration
+		policy := fastRetryPolicy()
+		policy.OnAttempt = func(attempt RetryAttempt) { waits = append(waits, attempt.Wait) }
+		olaMap := NewClient(WithBaseURL(APIPlaces, server.URL), WithAPIKey("mock-key"), WithRetryPolicy(policy))
+
+		_, err := olaMap.GeoCode("mock-address", Bounds{}, "")
+		assert.Nil(t, err)
+		assert.Equal(t, time.Second, waits[0])
+	})
+	t.Run("context cancelled during backoff", func(t *testing.T) {
+		server, calls := newFlakyServer(t, 10, http.StatusTooManyRequests, http.Header{"Retry-After": {"1"}}, "")
+		olaMap := NewClient(WithBaseURL(APIPlaces, server.URL), WithAPIKey("mock-key"), WithRetryPolicy(fastRetryPolicy()))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(20*time.Millisecond, cancel)
+		_, err := olaMap.GeoCodeWithContext(ctx, "mock-address", Bounds{}, "")
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, int32(1), *calls)
+	})
+	t.Run("retry after beyond the deadline", func(t *testing.T) {
+		server, calls := newFlakyServer(t, 10, http.StatusTooManyRequests, http.Header{"Retry-After": {"60"}}, "")
+		olaMap := NewClient(WithBaseURL(APIPlaces, server.URL), WithAPIKey("mock-key"), WithRetryPolicy(fastRetryPolicy()))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		_, err := olaMap.GeoCodeWithContext(ctx, "mock-address", Bounds{}, "")
+		assert.ErrorIs(t, err, ErrRateLimited)
+		assert.Less(t, time.Since(start), 200*time.Millisecond)
+		assert.Equal(t, int32(1), *calls)
+	})
+	t.Run("retry after beyond the limit", func(t *testing.T) {
+		server, calls := newFlakyServer(t, 10, http.StatusTooManyRequests, http.Header{"Retry-After": {"3600"}}, "")
+		var waits []time.Duration
+		policy := fastRetryPolicy()
+		policy.OnAttempt = func(attempt RetryAttempt) { waits = append(waits, attempt.Wait) }
+		olaMap := NewClient(WithBaseURL(APIPlaces, server.URL), WithAPIKey("mock-key"), WithRetryPolicy(policy))
+
+		_, err := olaMap.GeoCode("mock-address", Bounds{}, "")
+		var apiErr *APIError
+		assert.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusTooManyRequests, apiErr.StatusCode)
+		assert.Equal(t, []time.Duration{0}, waits)
+		assert.Equal(t, int32(1), *calls)
+	})
+	t.Run("retry directions and token fetch", func(t *testing.T) {
+		server, calls := newFlakyServer(t, 1, http.StatusBadGateway, nil, `{"access_token":"mock-token","expires_in":3600}`)
+		olaMap := NewClient(WithBaseURL(APIAuth, server.URL), WithRetryPolicy(fastRetryPolicy()))
+
+		err := olaMap.ConfigureAccessToken("mock-client", "mock-secret")
+		assert.Nil(t, err)
+		assert.Equal(t, "Bearer mock-token", olaMap.Token)
+		assert.Equal(t, int32(2), *calls)
+	})
+	t.Run("other posts are not retried", func(t *testing.T) {
+		server, calls := newFlakyServer(t, 1, http.StatusBadGateway, nil, "")
+		client := &http.Client{Transport: &retryTransport{policy: fastRetryPolicy()}}
+		req, _ := http.NewRequest("POST", server.URL, nil)
+		resp, err := client.Do(req)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+		assert.Equal(t, int32(1), *calls)
+	})
+}
+
+func TestRetryAfter(t *testing.T) {
+	wait, ok := retryAfter("3")
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, wait)
+
+	wait, ok = retryAfter(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), wait)
+
+	_, ok = retryAfter("soon")
+	assert.False(t, ok)
+}
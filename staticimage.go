@@ -0,0 +1,78 @@
+package golamap
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// StaticImage is a rendered static map image. Unless streaming was
+// requested, the image is read into Data; otherwise Body holds the open
+// response body, which the caller must close (WriteTo does so).
+type StaticImage struct {
+	ContentType string        // Content-Type reported by the API, e.g. image/png
+	Format      string        // Requested image format, e.g. png
+	Width       int           // Requested width in pixels
+	Height      int           // Requested height in pixels
+	Data        []byte        // Raw image bytes, when not streamed
+	Body        io.ReadCloser // Streaming image body, when streamed
+}
+
+// WriteTo writes the image to w, e.g. a file or an http.ResponseWriter, whose
+// Content-Type is set from the image. A streamed body is consumed and closed.
+func (s *StaticImage) WriteTo(w io.Writer) (int64, error) {
+	if rw, ok := w.(http.ResponseWriter); ok && s.ContentType != "" {
+		rw.Header().Set("Content-Type", s.ContentType)
+	}
+
+	if s.Body == nil {
+		n, err := w.Write(s.Data)
+		return int64(n), err
+	}
+
+	defer s.Close()
+	return io.Copy(w, s.Body)
+}
+
+// Reader returns a reader over the image, whether buffered or streamed
+func (s *StaticImage) Reader() io.Reader {
+	if s.Body != nil {
+		return s.Body
+	}
+	return bytes.NewReader(s.Data)
+}
+
+// Close releases a streamed body; it is a no-op for buffered images
+func (s *StaticImage) Close() error {
+	if s.Body == nil {
+		return nil
+	}
+	return s.Body.Close()
+}
+
+// readStaticImage fills image from a successful image response, taking
+// ownership of the body. Non-2xx responses become an *APIError.
+func readStaticImage(resp *http.Response, image *StaticImage, stream bool) (*StaticImage, error) {
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		return nil, newAPIError(resp)
+	}
+
+	image.ContentType = resp.Header.Get("Content-Type")
+	image.Format = strings.TrimPrefix(image.Format, ".")
+
+	if stream {
+		image.Body = resp.Body
+		return image, nil
+	}
+
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	image.Data = data
+
+	return image, nil
+}
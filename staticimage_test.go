@@ -0,0 +1,94 @@
+package golamap
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaticImage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("path") == "denied" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"status":"REQUEST_DENIED","error_message":"Invalid API key"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("mock-image"))
+	}))
+	defer server.Close()
+	olaMap := NewClient(WithBaseURL(APITiles, server.URL), WithAPIKey("mock-key"))
+	mapImage := MapImage{Stylename: "mock-style", Imagewidth: "90", Imageheight: "100", Imageformat: "png", Path: "mock-path"}
+
+	t.Run("buffered", func(t *testing.T) {
+		image, err := olaMap.StaticMapImage(mapImage)
+		assert.Nil(t, err)
+		assert.Equal(t, "image/png", image.ContentType)
+		assert.Equal(t, "png", image.Format)
+		assert.Equal(t, 90, image.Width)
+		assert.Equal(t, 100, image.Height)
+		assert.Equal(t, []byte("mock-image"), image.Data)
+		assert.Nil(t, image.Body)
+	})
+	t.Run("streamed", func(t *testing.T) {
+		streamed := mapImage
+		streamed.Stream = true
+		image, err := olaMap.StaticMapImage(streamed)
+		assert.Nil(t, err)
+		assert.Nil(t, image.Data)
+		data, _ := io.ReadAll(image.Reader())
+		assert.Equal(t, []byte("mock-image"), data)
+		assert.Nil(t, image.Close())
+	})
+	t.Run("non 2xx", func(t *testing.T) {
+		denied := mapImage
+		denied.Path = "denied"
+		_, err := olaMap.StaticMapImage(denied)
+		var apiErr *APIError
+		assert.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, "REQUEST_DENIED", apiErr.Status)
+		assert.ErrorIs(t, err, ErrUnauthorized)
+	})
+	t.Run("write to response", func(t *testing.T) {
+		streamed := mapImage
+		streamed.Stream = true
+		image, err := olaMap.StaticMapImage(streamed)
+		assert.Nil(t, err)
+
+		recorder := httptest.NewRecorder()
+		n, err := image.WriteTo(recorder)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(10), n)
+		assert.Equal(t, "image/png", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, "mock-image", recorder.Body.String())
+	})
+	t.Run("write to file", func(t *testing.T) {
+		image := &StaticImage{ContentType: "image/png", Data: []byte("mock-image")}
+		path := filepath.Join(t.TempDir(), "map.png")
+		file, _ := os.Create(path)
+		_, err := image.WriteTo(file)
+		file.Close()
+		assert.Nil(t, err)
+		data, _ := os.ReadFile(path)
+		assert.Equal(t, []byte("mock-image"), data)
+	})
+}
+
+func TestReadStaticImage(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"image/png"}},
+		Body:       io.NopCloser(bytes.NewBufferString("mock-image")),
+	}
+	image, err := readStaticImage(resp, &StaticImage{Format: ".png"}, false)
+	assert.Nil(t, err)
+	assert.Equal(t, "png", image.Format)
+	assert.Equal(t, []byte("mock-image"), image.Data)
+}
@@ -0,0 +1,283 @@
+package golamap
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ImageFormat is the encoding of a static map image
+type ImageFormat string
+
+const (
+	FormatPNG  ImageFormat = "png"
+	FormatJPEG ImageFormat = "jpg"
+	FormatWebP ImageFormat = "webp"
+)
+
+// Static map size and zoom limits
+const (
+	MaxStaticMapSize = 2048
+	MaxZoom          = 22
+)
+
+// MapMarker is a pin drawn on a static map
+type MapMarker struct {
+	Position Location
+	Color    string  // Named color or hex, e.g. red or #ff0000
+	Icon     string  // URL of a custom icon
+	Label    string  // Short text drawn on the marker
+	Scale    float64 // Marker size multiplier; zero keeps the default
+}
+
+// MapPath is a line or polygon drawn on a static map, given either as
+// Points or as an encoded Polyline
+type MapPath struct {
+	Points      []Location
+	Polyline    string // Encoded polyline, used instead of Points
+	StrokeColor string
+	StrokeWidth int
+	FillColor   string // Fills the path as a polygon
+}
+
+// StaticMapRequest builds a static map image request. Position the map
+// with Center or BBox, or leave both unset to fit the markers and paths.
+//
+//	req := golamap.NewStaticMap("default-light-standard").
+//		Center(golamap.Location{Lat: 12.93, Lng: 77.61}, 15).
+//		Size(600, 400).
+//		AddMarker(golamap.MapMarker{Position: golamap.Location{Lat: 12.93, Lng: 77.61}, Color: "red"})
+//	image, err := olaMap.StaticMap(ctx, req)
+type StaticMapRequest struct {
+	style   string
+	center  *Location
+	zoom    int
+	bbox    *Bounds
+	width   int
+	height  int
+	format  ImageFormat
+	scale   int
+	markers []MapMarker
+	paths   []MapPath
+	stream  bool
+}
+
+// NewStaticMap starts a static map request for style, as a 512x512 PNG
+func NewStaticMap(style string) *StaticMapRequest {
+	return &StaticMapRequest{style: style, width: 512, height: 512, format: FormatPNG, scale: 1}
+}
+
+// Center centers the map on center at zoom level zoom
+func (r *StaticMapRequest) Center(center Location, zoom int) *StaticMapRequest {
+	r.center, r.zoom, r.bbox = &center, zoom, nil
+	return r
+}
+
+// BBox fits the map to bounds. The static map API cannot draw bounds that
+// cross the antimeridian, which Validate rejects; use Center instead.
+func (r *StaticMapRequest) BBox(bounds Bounds) *StaticMapRequest {
+	r.bbox, r.center = &bounds, nil
+	return r
+}
+
+// Size sets the image size in pixels
+func (r *StaticMapRequest) Size(width, height int) *StaticMapRequest {
+	r.width, r.height = width, height
+	return r
+}
+
+// Format sets the image encoding
+func (r *StaticMapRequest) Format(format ImageFormat) *StaticMapRequest {
+	r.format = format
+	return r
+}
+
+// Retina renders the image at twice the pixel density
+func (r *StaticMapRequest) Retina() *StaticMapRequest {
+	r.scale = 2
+	return r
+}
+
+// AddMarker draws a marker on the map
+func (r *StaticMapRequest) AddMarker(marker MapMarker) *StaticMapRequest {
+	r.markers = append(r.markers, marker)
+	return r
+}
+
+// AddPath draws a path on the map
+func (r *StaticMapRequest) AddPath(path MapPath) *StaticMapRequest {
+	r.paths = append(r.paths, path)
+	return r
+}
+
+// Stream returns the image as StaticImage.Body instead of reading it into Data
+func (r *StaticMapRequest) Stream() *StaticMapRequest {
+	r.stream = true
+	return r
+}
+
+// Validate reports the first problem that would make the request fail
+func (r *StaticMapRequest) Validate() error {
+	if r.style == "" {
+		return validationError("Missing required static map parameter: 'style'")
+	}
+	if r.width <= 0 || r.height <= 0 || r.width > MaxStaticMapSize || r.height > MaxStaticMapSize {
+		return validationError(fmt.Sprintf("Invalid image size %dx%d: width and height must be between 1 and %d", r.width, r.height, MaxStaticMapSize))
+	}
+	switch r.format {
+	case FormatPNG, FormatJPEG, FormatWebP:
+	default:
+		return validationError(fmt.Sprintf("Invalid image format %q", r.format))
+	}
+
+	if r.center != nil {
+		if err := validateLocation("center", *r.center); err != nil {
+			return err
+		}
+		if r.zoom < 0 || r.zoom > MaxZoom {
+			return validationError(fmt.Sprintf("Invalid zoom level %d: must be between 0 and %d", r.zoom, MaxZoom))
+		}
+	}
+	if r.bbox != nil {
+		if err := validateLocation("bbox southwest", r.bbox.Southwest); err != nil {
+			return err
+		}
+		if err := validateLocation("bbox northeast", r.bbox.Northeast); err != nil {
+			return err
+		}
+		if r.bbox.Southwest.Lat >= r.bbox.Northeast.Lat || r.bbox.Southwest.Lng == r.bbox.Northeast.Lng {
+			return validationError("Invalid bbox: southwest corner must be below and left of northeast corner")
+		}
+		if r.bbox.Southwest.Lng > r.bbox.Northeast.Lng {
+			return validationError("Invalid bbox: bounds crossing the antimeridian are not supported")
+		}
+	}
+	if r.center == nil && r.bbox == nil && len(r.markers) == 0 && len(r.paths) == 0 {
+		return validationError("Static map needs a center, a bbox, or markers and paths to fit")
+	}
+
+	for i, marker := range r.markers {
+		if err := validateLocation(fmt.Sprintf("marker %d", i), marker.Position); err != nil {
+			return err
+		}
+		if marker.Scale < 0 {
+			return validationError(fmt.Sprintf("Invalid marker %d scale %v", i, marker.Scale))
+		}
+	}
+	for i, path := range r.paths {
+		if path.Polyline == "" && len(path.Points) < 2 {
+			return validationError(fmt.Sprintf("Invalid path %d: needs an encoded polyline or at least 2 points", i))
+		}
+		for _, point := range path.Points {
+			if err := validateLocation(fmt.Sprintf("path %d point", i), point); err != nil {
+				return err
+			}
+		}
+		if path.StrokeWidth < 0 {
+			return validationError(fmt.Sprintf("Invalid path %d stroke width %d", i, path.StrokeWidth))
+		}
+	}
+
+	return nil
+}
+
+func validateLocation(name string, location Location) error {
+	if location.Lat < -90 || location.Lat > 90 || location.Lng < -180 || location.Lng > 180 {
+		msg := fmt.Sprintf("Invalid %s coordinates %v,%v: latitude must be within ±90 and longitude within ±180", name, location.Lat, location.Lng)
+		if location.Lng >= -90 && location.Lng <= 90 && location.Lat >= -180 && location.Lat <= 180 {
+			msg += " (latitude and longitude swapped?)"
+		}
+		return validationError(msg)
+	}
+	return nil
+}
+
+// endpoint returns the static map endpoint and its URL path arguments
+func (r *StaticMapRequest) endpoint() (Endpoint, []interface{}) {
+	style := url.PathEscape(r.style)
+	switch {
+	case r.center != nil:
+		return EndpointStaticMapImageCenter, []interface{}{style, r.center.Lng, r.center.Lat, r.zoom, r.width, r.height, string(r.format)}
+	case r.bbox != nil:
+		return EndpointStaticMapImageBounded, []interface{}{style, r.bbox.Southwest.Lng, r.bbox.Southwest.Lat, r.bbox.Northeast.Lng, r.bbox.Northeast.Lat, r.width, r.height, string(r.format)}
+	default:
+		return EndpointStaticMapImage, []interface{}{style, r.width, r.height, string(r.format)}
+	}
+}
+
+// query encodes markers, paths and scale as query parameters. Coordinates
+// are written lng,lat and styles as |key:value suffixes, e.g.
+// marker=77.61,12.93|red|scale:0.9 and path=77.61,12.93|77.62,12.94|width:4.
+func (r *StaticMapRequest) query() url.Values {
+	query := url.Values{}
+	for _, marker := range r.markers {
+		parts := []string{formatLngLat(marker.Position)}
+		if marker.Color != "" {
+			parts = append(parts, marker.Color)
+		}
+		if marker.Scale > 0 {
+			parts = append(parts, "scale:"+strconv.FormatFloat(marker.Scale, 'f', -1, 64))
+		}
+		if marker.Icon != "" {
+			parts = append(parts, "icon:"+marker.Icon)
+		}
+		if marker.Label != "" {
+			parts = append(parts, "label:"+marker.Label)
+		}
+		query.Add("marker", strings.Join(parts, "|"))
+	}
+
+	for _, path := range r.paths {
+		var parts []string
+		if path.Polyline != "" {
+			parts = append(parts, "enc:"+path.Polyline)
+		} else {
+			for _, point := range path.Points {
+				parts = append(parts, formatLngLat(point))
+			}
+		}
+		if path.StrokeWidth > 0 {
+			parts = append(parts, "width:"+strconv.Itoa(path.StrokeWidth))
+		}
+		if path.StrokeColor != "" {
+			parts = append(parts, "stroke:"+path.StrokeColor)
+		}
+		if path.FillColor != "" {
+			parts = append(parts, "fill:"+path.FillColor)
+		}
+		query.Add("path", strings.Join(parts, "|"))
+	}
+
+	if r.scale > 1 {
+		query.Set("scale", strconv.Itoa(r.scale))
+	}
+
+	return query
+}
+
+func formatLngLat(location Location) string {
+	return strconv.FormatFloat(location.Lng, 'f', -1, 64) + "," + strconv.FormatFloat(location.Lat, 'f', -1, 64)
+}
+
+// StaticMap renders the static map described by req
+func (o *OLAMap) StaticMap(ctx context.Context, req *StaticMapRequest) (*StaticImage, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	cred, err := o.credential(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, args := req.endpoint()
+	apiURL := o.endpointURL(endpoint, args...)
+	if query := req.query(); len(query) > 0 {
+		apiURL += "?" + query.Encode()
+	}
+
+	image := &StaticImage{Width: req.width, Height: req.height, Format: string(req.format)}
+	return o.getStaticImage(ctx, endpoint, apiURL, cred, image, req.stream)
+}
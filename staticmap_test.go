@@ -0,0 +1,78 @@
+package golamap
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaticMap(t *testing.T) {
+	var lastRequest *http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastRequest = r
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("mock-image"))
+	}))
+	defer server.Close()
+	olaMap := NewClient(WithBaseURL(APITiles, server.URL), WithToken("mockToken"))
+	center := Location{Lat: 12.93, Lng: 77.61}
+
+	t.Run("center with markers", func(t *testing.T) {
+		req := NewStaticMap("default-light-standard").
+			Center(center, 15).
+			Size(600, 400).
+			Retina().
+			AddMarker(MapMarker{Position: center, Color: "red", Scale: 0.9, Label: "A"})
+		image, err := olaMap.StaticMap(context.Background(), req)
+		assert.Nil(t, err)
+		assert.Equal(t, 600, image.Width)
+		assert.Equal(t, "/tiles/v1/styles/default-light-standard/static/77.610000,12.930000,15/600x400.png", lastRequest.URL.Path)
+		assert.Equal(t, []string{"77.61,12.93|red|scale:0.9|label:A"}, lastRequest.URL.Query()["marker"])
+		assert.Equal(t, "2", lastRequest.URL.Query().Get("scale"))
+	})
+	t.Run("bbox with paths", func(t *testing.T) {
+		req := NewStaticMap("default-light-standard").
+			BBox(Bounds{Southwest: Location{Lat: 12.9, Lng: 77.5}, Northeast: Location{Lat: 13, Lng: 77.7}}).
+			Format(FormatJPEG).
+			AddPath(MapPath{Points: []Location{{Lat: 12.91, Lng: 77.51}, {Lat: 12.99, Lng: 77.69}}, StrokeColor: "#00ff44", StrokeWidth: 4}).
+			AddPath(MapPath{Polyline: "_p~iF~ps|U_ulLnnqC", FillColor: "#ff000055"})
+		_, err := olaMap.StaticMap(context.Background(), req)
+		assert.Nil(t, err)
+		assert.Equal(t, "/tiles/v1/styles/default-light-standard/static/77.500000,12.900000,77.700000,13.000000/512x512.jpg", lastRequest.URL.Path)
+		assert.Equal(t, []string{"77.51,12.91|77.69,12.99|width:4|stroke:#00ff44", "enc:_p~iF~ps|U_ulLnnqC|fill:#ff000055"}, lastRequest.URL.Query()["path"])
+	})
+	t.Run("auto fit", func(t *testing.T) {
+		req := NewStaticMap("default-light-standard").AddMarker(MapMarker{Position: center})
+		_, err := olaMap.StaticMap(context.Background(), req)
+		assert.Nil(t, err)
+		assert.Equal(t, "/tiles/v1/styles/default-light-standard/static/auto/512x512.png", lastRequest.URL.Path)
+	})
+}
+
+func TestStaticMapValidate(t *testing.T) {
+	center := Location{Lat: 12.93, Lng: 77.61}
+	tests := map[string]struct {
+		req *StaticMapRequest
+		err string
+	}{
+		"missing style":     {NewStaticMap("").Center(center, 10), "Missing required static map parameter: 'style'"},
+		"zero size":         {NewStaticMap("mock-style").Center(center, 10).Size(0, 10), "Invalid image size 0x10: width and height must be between 1 and 2048"},
+		"bad format":        {NewStaticMap("mock-style").Center(center, 10).Format("gif"), `Invalid image format "gif"`},
+		"swapped center":    {NewStaticMap("mock-style").Center(Location{Lat: 77.61, Lng: 212.93}, 10), "Invalid center coordinates 77.61,212.93: latitude must be within ±90 and longitude within ±180"},
+		"zoom too deep":     {NewStaticMap("mock-style").Center(center, 23), "Invalid zoom level 23: must be between 0 and 22"},
+		"inverted bbox":     {NewStaticMap("mock-style").BBox(Bounds{Southwest: Location{Lat: 13, Lng: 77.7}, Northeast: Location{Lat: 12.9, Lng: 77.5}}), "Invalid bbox: southwest corner must be below and left of northeast corner"},
+		"antimeridian bbox": {NewStaticMap("mock-style").BBox(Location{Lat: 0, Lng: 179.99}.BoundingBox(5000)), "Invalid bbox: bounds crossing the antimeridian are not supported"},
+		"nothing to fit":    {NewStaticMap("mock-style"), "Static map needs a center, a bbox, or markers and paths to fit"},
+		"single point path": {NewStaticMap("mock-style").AddPath(MapPath{Points: []Location{center}}), "Invalid path 0: needs an encoded polyline or at least 2 points"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := test.req.Validate()
+			assert.EqualError(t, err, test.err)
+			assert.ErrorIs(t, err, ErrValidation)
+		})
+	}
+}
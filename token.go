@@ -0,0 +1,214 @@
+package golamap
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tokenRefreshLeeway is how long before expiry a token is proactively
+// refreshed, capped at half the token's lifetime for short-lived tokens
+const tokenRefreshLeeway = time.Minute
+
+// Credential authenticates a single request to the Olamaps API. Exactly one
+// of AccessToken and APIKey is normally set.
+type Credential struct {
+	AccessToken string // Authorization header value, e.g. "Bearer ..."
+	APIKey      string // Sent as the api_key query parameter
+}
+
+// TokenSource supplies the credential for each request. OLAMap consults it
+// on every call, so a source may rotate secrets at any time. Sources that
+// also implement Invalidate(Credential) are told when the API rejects a
+// credential, and the request is retried once with a fresh one.
+type TokenSource interface {
+	Credential(ctx context.Context) (Credential, error)
+}
+
+// TokenSourceFunc adapts a function to a TokenSource, e.g. one reading a
+// secret from a file, an environment variable or a vault
+type TokenSourceFunc func(ctx context.Context) (Credential, error)
+
+func (f TokenSourceFunc) Credential(ctx context.Context) (Credential, error) {
+	return f(ctx)
+}
+
+type staticTokenSource Credential
+
+func (s staticTokenSource) Credential(ctx context.Context) (Credential, error) {
+	return Credential(s), nil
+}
+
+// StaticToken returns a TokenSource that always sends the given OAuth
+// access token. The "Bearer " prefix is added if missing.
+func StaticToken(token string) TokenSource {
+	if !strings.HasPrefix(token, "Bearer ") {
+		token = "Bearer " + token
+	}
+	return staticTokenSource{AccessToken: token}
+}
+
+// APIKey returns a TokenSource that authenticates with an api_key query
+// parameter instead of an OAuth token
+func APIKey(key string) TokenSource {
+	return staticTokenSource{APIKey: key}
+}
+
+// ClientCredentialsSource keeps an OAuth access token fresh for one set of
+// client credentials. It is safe for concurrent use; concurrent callers that
+// find the token stale share a single refresh, which each caller stops
+// waiting for when its own context is done.
+type ClientCredentialsSource struct {
+	TokenURL   string       // Token endpoint; defaults to the Olamaps token URL
+	HTTPClient *http.Client // Client for token requests; defaults to http.DefaultClient
+
+	clientID     string
+	clientSecret string
+	now          func() time.Time
+
+	mu        sync.Mutex
+	token     string        // Authorization header value, e.g. "Bearer ..."
+	refreshAt time.Time     // When the token is refreshed; zero if it never expires
+	refresh   *tokenRefresh // Fetch in flight, if any
+}
+
+// tokenRefresh is a token fetch shared by the callers waiting on it
+type tokenRefresh struct {
+	done    chan struct{}
+	token   string
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// NewClientCredentialsSource returns a TokenSource using the client_credentials grant
+func NewClientCredentialsSource(clientID, clientSecret string) *ClientCredentialsSource {
+	return &ClientCredentialsSource{
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		now:          time.Now,
+	}
+}
+
+// Credential returns a valid access token, fetching a new one when none is
+// cached or the cached one is about to expire. The fetch runs until it
+// completes or every caller waiting on it has given up.
+func (t *ClientCredentialsSource) Credential(ctx context.Context) (Credential, error) {
+	t.mu.Lock()
+	if t.token != "" && (t.refreshAt.IsZero() || t.now().Before(t.refreshAt)) {
+		token := t.token
+		t.mu.Unlock()
+		return Credential{AccessToken: token}, nil
+	}
+
+	refresh := t.refresh
+	if refresh == nil {
+		fetchCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		refresh = &tokenRefresh{done: make(chan struct{}), cancel: cancel}
+		t.refresh = refresh
+		go t.runRefresh(fetchCtx, refresh)
+	}
+	refresh.waiters++
+	t.mu.Unlock()
+
+	select {
+	case <-refresh.done:
+		if refresh.err != nil {
+			return Credential{}, refresh.err
+		}
+		return Credential{AccessToken: refresh.token}, nil
+	case <-ctx.Done():
+		t.mu.Lock()
+		refresh.waiters--
+		if refresh.waiters == 0 {
+			refresh.cancel()
+			if t.refresh == refresh {
+				t.refresh = nil
+			}
+		}
+		t.mu.Unlock()
+		return Credential{}, ctx.Err()
+	}
+}
+
+// runRefresh fetches a token for refresh and caches it
+func (t *ClientCredentialsSource) runRefresh(ctx context.Context, refresh *tokenRefresh) {
+	tokenResponse, err := t.fetchToken(ctx)
+	refresh.cancel()
+
+	t.mu.Lock()
+	if err == nil {
+		t.token = "Bearer " + tokenResponse.AccessToken
+		t.refreshAt = time.Time{}
+		if tokenResponse.ExpiresIn > 0 {
+			lifetime := time.Duration(tokenResponse.ExpiresIn) * time.Second
+			t.refreshAt = t.now().Add(lifetime - min(tokenRefreshLeeway, lifetime/2))
+		}
+		refresh.token = t.token
+	}
+	refresh.err = err
+	if t.refresh == refresh {
+		t.refresh = nil
+	}
+	t.mu.Unlock()
+
+	close(refresh.done)
+}
+
+// Invalidate drops the cached token if it is still the one the API rejected,
+// so the next call to Credential fetches a new one. A token refreshed
+// meanwhile by another goroutine is kept.
+func (t *ClientCredentialsSource) Invalidate(rejected Credential) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token == rejected.AccessToken {
+		t.token = ""
+	}
+}
+
+// fetchToken requests an access token using the client_credentials grant
+func (t *ClientCredentialsSource) fetchToken(ctx context.Context) (TokenResponse, error) {
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	form.Set("scope", "openid")
+	form.Set("client_id", t.clientID)
+	form.Set("client_secret", t.clientSecret)
+
+	tokenURL := t.TokenURL
+	if tokenURL == "" {
+		tokenURL = DefaultAuthBaseURL + endpoints[EndpointToken].path
+	}
+
+	client := t.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(withEndpoint(ctx, EndpointToken), "POST", tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return TokenResponse{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return TokenResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return TokenResponse{}, newAPIError(resp)
+	}
+
+	var tokenResponse TokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResponse); err != nil {
+		return TokenResponse{}, err
+	}
+
+	return tokenResponse, nil
+}
@@ -0,0 +1,304 @@
+package golamap
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newMockTokenServer starts a token endpoint issuing "token-N" tokens
+func newMockTokenServer(t *testing.T, fetches *int32) string {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(fetches, 1)
+		fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":3600}`, n)
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+type unauthorizedOnce struct {
+	urls   []string
+	tokens []string
+}
+
+func (u *unauthorizedOnce) SendOlaMapRequest(ctx context.Context, method, url, requestID, oauthToken string, responseObj interface{}) error {
+	u.urls = append(u.urls, url)
+	u.tokens = append(u.tokens, oauthToken)
+	if len(u.tokens) == 1 {
+		return &APIError{StatusCode: http.StatusUnauthorized, Err: ErrUnauthorized}
+	}
+	return nil
+}
+
+func TestClientCredentialsSource(t *testing.T) {
+	t.Run("refresh before expiry", func(t *testing.T) {
+		var fetches int32
+		now := time.Now()
+		tokens := NewClientCredentialsSource("mock-client", "mock-secret")
+		tokens.TokenURL = newMockTokenServer(t, &fetches)
+		tokens.now = func() time.Time { return now }
+
+		cred, err := tokens.Credential(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, "Bearer token-1", cred.AccessToken)
+
+		now = now.Add(50 * time.Minute)
+		cred, _ = tokens.Credential(context.Background())
+		assert.Equal(t, "Bearer token-1", cred.AccessToken)
+
+		now = now.Add(9*time.Minute + 30*time.Second)
+		cred, _ = tokens.Credential(context.Background())
+		assert.Equal(t, "Bearer token-2", cred.AccessToken)
+	})
+	t.Run("short-lived tokens are cached", func(t *testing.T) {
+		var fetches int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n := atomic.AddInt32(&fetches, 1)
+			fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":60}`, n)
+		}))
+		defer server.Close()
+		now := time.Now()
+		tokens := NewClientCredentialsSource("mock-client", "mock-secret")
+		tokens.TokenURL = server.URL
+		tokens.now = func() time.Time { return now }
+
+		for i := 0; i < 5; i++ {
+			cred, _ := tokens.Credential(context.Background())
+			assert.Equal(t, "Bearer token-1", cred.AccessToken)
+		}
+
+		now = now.Add(30 * time.Second)
+		cred, _ := tokens.Credential(context.Background())
+		assert.Equal(t, "Bearer token-2", cred.AccessToken)
+	})
+	t.Run("concurrent callers share one fetch", func(t *testing.T) {
+		var fetches int32
+		tokens := NewClientCredentialsSource("mock-client", "mock-secret")
+		tokens.TokenURL = newMockTokenServer(t, &fetches)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				tokens.Credential(context.Background())
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int32(1), fetches)
+	})
+	t.Run("callers leave a slow fetch through their own context", func(t *testing.T) {
+		release := make(chan struct{})
+		var fetches int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n := atomic.AddInt32(&fetches, 1)
+			<-release
+			fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":3600}`, n)
+		}))
+		defer server.Close()
+		defer close(release)
+		tokens := NewClientCredentialsSource("mock-client", "mock-secret")
+		tokens.TokenURL = server.URL
+
+		waiting := make(chan error)
+		go func() {
+			_, err := tokens.Credential(context.Background())
+			waiting <- err
+		}()
+		assert.Eventually(t, func() bool { return atomic.LoadInt32(&fetches) == 1 }, time.Second, time.Millisecond)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		_, err := tokens.Credential(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), 500*time.Millisecond)
+
+		release <- struct{}{}
+		assert.Nil(t, <-waiting)
+		cred, err := tokens.Credential(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, "Bearer token-1", cred.AccessToken)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&fetches))
+	})
+	t.Run("invalidate keeps a newer token", func(t *testing.T) {
+		var fetches int32
+		tokens := NewClientCredentialsSource("mock-client", "mock-secret")
+		tokens.TokenURL = newMockTokenServer(t, &fetches)
+		tokens.Credential(context.Background())
+
+		tokens.Invalidate(Credential{AccessToken: "Bearer stale"})
+		cred, _ := tokens.Credential(context.Background())
+		assert.Equal(t, "Bearer token-1", cred.AccessToken)
+
+		tokens.Invalidate(cred)
+		cred, _ = tokens.Credential(context.Background())
+		assert.Equal(t, "Bearer token-2", cred.AccessToken)
+	})
+}
+
+func TestConfigureAccessToken(t *testing.T) {
+	t.Run("retry once on 401", func(t *testing.T) {
+		var fetches int32
+		olaMap := NewClient(WithBaseURL(APIAuth, newMockTokenServer(t, &fetches)))
+		err := olaMap.ConfigureAccessToken("mock-client", "mock-secret")
+		assert.Nil(t, err)
+		assert.Equal(t, "Bearer token-1", olaMap.Token)
+
+		mocking := &unauthorizedOnce{}
+		olaMap.HttpService = mocking
+		_, err = olaMap.PlaceAutoComplete("mock-input")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"Bearer token-1", "Bearer token-2"}, mocking.tokens)
+	})
+	t.Run("static token is not refreshed", func(t *testing.T) {
+		olaMap := &OLAMap{Token: "mockToken", HttpService: &unauthorizedOnce{}}
+		_, err := olaMap.PlaceAutoComplete("mock-input")
+		assert.ErrorIs(t, err, ErrUnauthorized)
+		assert.Equal(t, 1, len(olaMap.HttpService.(*unauthorizedOnce).tokens))
+	})
+	t.Run("403 keeps the token", func(t *testing.T) {
+		var fetches, calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasSuffix(r.URL.Path, "/token") {
+				n := atomic.AddInt32(&fetches, 1)
+				fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":3600}`, n)
+				return
+			}
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"status":"REQUEST_DENIED","error_message":"API not enabled"}`))
+		}))
+		defer server.Close()
+		olaMap := NewClient(WithBaseURL(APIAuth, server.URL), WithBaseURL(APIPlaces, server.URL),
+			WithClientCredentials("mock-client", "mock-secret"))
+
+		for i := 0; i < 3; i++ {
+			_, err := olaMap.PlaceAutoComplete("mock-input")
+			assert.ErrorIs(t, err, ErrUnauthorized)
+		}
+		assert.Equal(t, int32(1), atomic.LoadInt32(&fetches))
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	})
+}
+
+func TestTokenSource(t *testing.T) {
+	t.Run("static token", func(t *testing.T) {
+		cred, err := StaticToken("mockToken").Credential(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, Credential{AccessToken: "Bearer mockToken"}, cred)
+
+		cred, _ = StaticToken("Bearer mockToken").Credential(context.Background())
+		assert.Equal(t, "Bearer mockToken", cred.AccessToken)
+	})
+	t.Run("api key", func(t *testing.T) {
+		mocking := &unauthorizedOnce{}
+		olaMap := &OLAMap{TokenSource: APIKey("mock key"), HttpService: mocking}
+		olaMap.PlaceAutoComplete("mock-input")
+		assert.Equal(t, "https://api.olamaps.io/places/v1/autocomplete?input=mock-input&api_key=mock+key", mocking.urls[0])
+		assert.Equal(t, "", mocking.tokens[0])
+	})
+	t.Run("consulted per request", func(t *testing.T) {
+		var calls int
+		source := TokenSourceFunc(func(ctx context.Context) (Credential, error) {
+			calls++
+			return Credential{AccessToken: fmt.Sprintf("Bearer secret-%d", calls)}, nil
+		})
+		mocking := &unauthorizedOnce{}
+		olaMap := &OLAMap{TokenSource: source, HttpService: mocking}
+		olaMap.PlaceAutoComplete("mock-input")
+		olaMap.PlaceAutoComplete("mock-input")
+		assert.Equal(t, []string{"Bearer secret-1", "Bearer secret-2"}, mocking.tokens)
+	})
+	t.Run("source error", func(t *testing.T) {
+		source := TokenSourceFunc(func(ctx context.Context) (Credential, error) {
+			return Credential{}, errors.New("mock-vault-error")
+		})
+		olaMap := &OLAMap{TokenSource: source}
+		_, err := olaMap.PlaceAutoComplete("mock-input")
+		assert.EqualError(t, err, "mock-vault-error")
+	})
+	t.Run("empty credential", func(t *testing.T) {
+		source := TokenSourceFunc(func(ctx context.Context) (Credential, error) {
+			return Credential{}, nil
+		})
+		olaMap := &OLAMap{TokenSource: source}
+		_, err := olaMap.PlaceAutoComplete("mock-input")
+		assert.EqualError(t, err, "Invalid OAuth token")
+	})
+}
+
+// fixtureService decodes a canned response body, recording the request URLs
+type fixtureService struct {
+	body string
+	urls []string
+}
+
+func (f *fixtureService) SendOlaMapRequest(ctx context.Context, method, url, requestID, oauthToken string, responseObj interface{}) error {
+	f.urls = append(f.urls, url)
+	return json.Unmarshal([]byte(f.body), responseObj)
+}
+
+func TestAPIKey(t *testing.T) {
+	t.Run("appended to endpoint", func(t *testing.T) {
+		mocking := &fixtureService{body: GeoCodeResponse}
+		olaMap := InitializeWithAPIKey("mock-request-id", "mock-key")
+		olaMap.HttpService = mocking
+		_, err := olaMap.GeoCode("mock-address", Bounds{}, "")
+		assert.Nil(t, err)
+		assert.Equal(t, "https://api.olamaps.io/places/v1/geocode?address=mock-address&bounds=&language=&api_key=mock-key", mocking.urls[0])
+	})
+	t.Run("token takes precedence", func(t *testing.T) {
+		mocking := &unauthorizedOnce{}
+		olaMap := &OLAMap{Token: "mockToken", APIKey: "mock-key", HttpService: mocking}
+		olaMap.GetMapStyle()
+		assert.NotContains(t, mocking.urls[0], "api_key")
+		assert.Equal(t, "mockToken", mocking.tokens[0])
+	})
+	t.Run("style json", func(t *testing.T) {
+		olaMap := &OLAMap{APIKey: "mock-key", HttpService: &fixtureService{body: StyleDetailResponse}}
+		style, err := olaMap.GetStyleDetails("default-light-standard")
+		assert.Nil(t, err)
+		assert.Equal(t, "https://api.olamaps.io/tiles/vector/v1/fonts/{fontstack}/{range}.pbf?api_key=mock-key", style.Glyphs)
+		for _, source := range style.Sources {
+			assert.Equal(t, "https://api.olamaps.io/tiles/vector/v1/data/planet.json?api_key=mock-key", source.URL)
+		}
+	})
+	t.Run("tile json", func(t *testing.T) {
+		body := `{"tiles":["https://api.olamaps.io/tiles/vector/v1/data/planet/{z}/{x}/{y}.pbf?key="]}`
+		olaMap := &OLAMap{APIKey: "mock-key", HttpService: &fixtureService{body: body}}
+		data, err := olaMap.ArrayOfData("planet")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"https://api.olamaps.io/tiles/vector/v1/data/planet/{z}/{x}/{y}.pbf?key=&api_key=mock-key"}, data.Tiles)
+	})
+	t.Run("map styles", func(t *testing.T) {
+		olaMap := &OLAMap{APIKey: "mock-key", HttpService: &fixtureService{body: MapStyleResponse}}
+		styles, err := olaMap.GetMapStyle()
+		assert.Nil(t, err)
+		assert.Equal(t, "https://api.olamaps.io/tiles/vector/v1/styles/default-light-standard/style.json?api_key=mock-key", styles[0].URL)
+	})
+	t.Run("redacted from errors", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+		olaMap := NewClient(WithBaseURL(APIPlaces, server.URL), WithBaseURL(APITiles, server.URL), WithAPIKey("secret-key"))
+
+		_, err := olaMap.GeoCode("mock-address", Bounds{}, "")
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "api_key=REDACTED")
+		assert.NotContains(t, err.Error(), "secret-key")
+
+		_, err = olaMap.StaticMapImage(MapImage{Stylename: "default-light-standard", Imagewidth: "100", Imageheight: "100", Imageformat: "png", Path: "77.5,12.9|77.6,13.0"})
+		assert.NotNil(t, err)
+		assert.NotContains(t, err.Error(), "secret-key")
+	})
+}
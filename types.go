@@ -2,7 +2,7 @@ package golamap
 
 type NearBySearch struct {
 	Layers       string
-	Location     string
+	Location     LatLng
 	Types        string
 	Radius       string
 	Strictbounds string
@@ -17,6 +17,7 @@ type MapImage struct {
 	Imageformat string
 	Path        string
 	Markers     []string
+	Stream      bool // Return the image as StaticImage.Body instead of reading it into Data
 }
 
 type MapImageBounded struct {
@@ -30,6 +31,7 @@ type MapImageBounded struct {
 	Imageformat string
 	Markers     []string
 	Path        string
+	Stream      bool // Return the image as StaticImage.Body instead of reading it into Data
 }
 
 type MapImageCenter struct {
@@ -42,11 +44,50 @@ type MapImageCenter struct {
 	Imageformat string
 	Markers     []string
 	Path        string
+	Stream      bool // Return the image as StaticImage.Body instead of reading it into Data
+}
+
+// TravelMode selects how a route is travelled
+type TravelMode string
+
+const (
+	TravelModeDriving TravelMode = "driving"
+	TravelModeWalking TravelMode = "walking"
+	TravelModeBike    TravelMode = "bike"
+	TravelModeAuto    TravelMode = "auto"
+)
+
+// Overview selects the detail of Route.OverviewPolyline
+type Overview string
+
+const (
+	OverviewFull       Overview = "full"
+	OverviewSimplified Overview = "simplified"
+	OverviewNone       Overview = "false"
+)
+
+// DirectionsRequest holds the parameters of GetDirectionsWithOptions.
+// Origin and Destination are required; other zero values leave the API
+// defaults.
+type DirectionsRequest struct {
+	Origin            LatLng
+	Destination       LatLng
+	Waypoints         Path // Intermediate stops, visited in order unless OptimizeWaypoints is set
+	OptimizeWaypoints bool // Let the API reorder Waypoints; see Route.WaypointOrder
+	Mode              TravelMode
+	Alternatives      bool // Return alternative routes
+	AvoidTolls        bool
+	AvoidHighways     bool
+	AvoidFerries      bool
+	OmitSteps         bool // Leave out turn-by-turn steps
+	Overview          Overview
+	Language          string
+	TrafficMetadata   bool // Include Route.TravelAdvisory
 }
 
 type TextSearch struct {
 	Input    string
-	Location string
+	Location LatLng // Optional location to bias results towards
 	Radius   string
 	Types    string
 	Size     string
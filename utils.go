@@ -1,16 +1,19 @@
 package golamap
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
 )
 
-type OlaRequest struct{}
+type OlaRequest struct {
+	Client *http.Client // Defaults to http.DefaultClient
+}
 
-func (o *OlaRequest) SendOlaMapRequest(method, url, requestID, oauthToken string, responseObj interface{}) error {
+func (o *OlaRequest) SendOlaMapRequest(ctx context.Context, method, url, requestID, oauthToken string, responseObj interface{}) error {
 	// Create a new request
-	req, err := http.NewRequest(method, url, nil)
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
 	if err != nil {
 		return err
 	}
@@ -21,13 +24,20 @@ func (o *OlaRequest) SendOlaMapRequest(method, url, requestID, oauthToken string
 	}
 
 	// Send the request
-	client := &http.Client{}
+	client := o.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
 	resp, err := client.Do(req)
 	if err != nil {
-		return err
+		return redactError(err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return newAPIError(resp)
+	}
+
 	// Read the response body
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -43,6 +53,17 @@ func (o *OlaRequest) SendOlaMapRequest(method, url, requestID, oauthToken string
 	return nil
 }
 
+// Untyped adapts a typed OLAMap call to the pre-typed (interface{}, error)
+// signature, for callers that have not migrated yet:
+//
+//	resp, err := golamap.Untyped(olaMap.GetDirections(origin, destination))
+func Untyped[T any](v T, err error) (interface{}, error) {
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
 // ParseJSONBody parses the JSON request body into the provided struct.
 func ParseJSONBody(r *http.Request, v interface{}) error {
 	body, err := io.ReadAll(r.Body)
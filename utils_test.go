@@ -2,8 +2,11 @@ package golamap
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -28,3 +31,39 @@ func TestParseJSONBody(t *testing.T) {
 		assert.NotNil(t, err)
 	})
 }
+
+func TestSendOlaMapRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer server.Close()
+
+	t.Run("success", func(t *testing.T) {
+		var responseLoader map[string]string
+		err := (&OlaRequest{}).SendOlaMapRequest(context.Background(), "GET", server.URL, "mock-request-id", "mockToken", &responseLoader)
+		assert.Nil(t, err)
+		assert.Equal(t, "ok", responseLoader["status"])
+	})
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		var responseLoader map[string]string
+		err := (&OlaRequest{}).SendOlaMapRequest(ctx, "GET", server.URL, "mock-request-id", "mockToken", &responseLoader)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestUntyped(t *testing.T) {
+	t.Run("typed value", func(t *testing.T) {
+		resp, err := Untyped(Directions{Status: "ok"}, nil)
+		assert.Nil(t, err)
+		directions, ok := resp.(Directions)
+		assert.True(t, ok)
+		assert.Equal(t, "ok", directions.Status)
+	})
+	t.Run("error", func(t *testing.T) {
+		resp, err := Untyped(Directions{}, errors.New("mock-error"))
+		assert.Nil(t, resp)
+		assert.EqualError(t, err, "mock-error")
+	})
+}